@@ -23,6 +23,43 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
+type TradingState int32
+
+const (
+	TradingState_CONTINUOUS  TradingState = 0
+	TradingState_PRE_OPEN    TradingState = 1
+	TradingState_POST_ONLY   TradingState = 2
+	TradingState_CANCEL_ONLY TradingState = 3
+	TradingState_HALTED      TradingState = 4
+	TradingState_CLOSED      TradingState = 5
+)
+
+var TradingState_name = map[int32]string{
+	0: "CONTINUOUS",
+	1: "PRE_OPEN",
+	2: "POST_ONLY",
+	3: "CANCEL_ONLY",
+	4: "HALTED",
+	5: "CLOSED",
+}
+
+var TradingState_value = map[string]int32{
+	"CONTINUOUS":  0,
+	"PRE_OPEN":    1,
+	"POST_ONLY":   2,
+	"CANCEL_ONLY": 3,
+	"HALTED":      4,
+	"CLOSED":      5,
+}
+
+func (x TradingState) String() string {
+	return proto.EnumName(TradingState_name, int32(x))
+}
+
+func (TradingState) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_3544f9578582e495, []int{0}
+}
+
 type Order_Side int32
 
 const (
@@ -51,21 +88,33 @@ func (Order_Side) EnumDescriptor() ([]byte, []int) {
 type Order_State int32
 
 const (
-	Order_PENDING   Order_State = 0
-	Order_FILLED    Order_State = 1
-	Order_CANCELLED Order_State = 2
+	Order_PENDING          Order_State = 0
+	Order_FILLED           Order_State = 1
+	Order_CANCELLED        Order_State = 2
+	Order_PARTIALLY_FILLED Order_State = 3
+	Order_REJECTED         Order_State = 4
+	Order_TRIGGERED        Order_State = 5
+	Order_EXPIRED          Order_State = 6
 )
 
 var Order_State_name = map[int32]string{
 	0: "PENDING",
 	1: "FILLED",
 	2: "CANCELLED",
+	3: "PARTIALLY_FILLED",
+	4: "REJECTED",
+	5: "TRIGGERED",
+	6: "EXPIRED",
 }
 
 var Order_State_value = map[string]int32{
-	"PENDING":   0,
-	"FILLED":    1,
-	"CANCELLED": 2,
+	"PENDING":          0,
+	"FILLED":           1,
+	"CANCELLED":        2,
+	"PARTIALLY_FILLED": 3,
+	"REJECTED":         4,
+	"TRIGGERED":        5,
+	"EXPIRED":          6,
 }
 
 func (x Order_State) String() string {
@@ -76,18 +125,229 @@ func (Order_State) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_3544f9578582e495, []int{0, 1}
 }
 
+type Order_SelfTradePrevention int32
+
+const (
+	Order_CANCEL_NEWEST        Order_SelfTradePrevention = 0
+	Order_CANCEL_OLDEST        Order_SelfTradePrevention = 1
+	Order_CANCEL_BOTH          Order_SelfTradePrevention = 2
+	Order_DECREMENT_AND_CANCEL Order_SelfTradePrevention = 3
+)
+
+var Order_SelfTradePrevention_name = map[int32]string{
+	0: "CANCEL_NEWEST",
+	1: "CANCEL_OLDEST",
+	2: "CANCEL_BOTH",
+	3: "DECREMENT_AND_CANCEL",
+}
+
+var Order_SelfTradePrevention_value = map[string]int32{
+	"CANCEL_NEWEST":        0,
+	"CANCEL_OLDEST":        1,
+	"CANCEL_BOTH":          2,
+	"DECREMENT_AND_CANCEL": 3,
+}
+
+func (x Order_SelfTradePrevention) String() string {
+	return proto.EnumName(Order_SelfTradePrevention_name, int32(x))
+}
+
+func (Order_SelfTradePrevention) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_3544f9578582e495, []int{0, 2}
+}
+
+type Order_Trigger int32
+
+const (
+	Order_DEFAULT_TRIGGER Order_Trigger = 0
+	Order_RISES_TO        Order_Trigger = 1
+	Order_FALLS_TO        Order_Trigger = 2
+)
+
+var Order_Trigger_name = map[int32]string{
+	0: "DEFAULT_TRIGGER",
+	1: "RISES_TO",
+	2: "FALLS_TO",
+}
+
+var Order_Trigger_value = map[string]int32{
+	"DEFAULT_TRIGGER": 0,
+	"RISES_TO":        1,
+	"FALLS_TO":        2,
+}
+
+func (x Order_Trigger) String() string {
+	return proto.EnumName(Order_Trigger_name, int32(x))
+}
+
+func (Order_Trigger) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_3544f9578582e495, []int{0, 3}
+}
+
+type Order_StopType int32
+
+const (
+	Order_DEFAULT_STOP_TYPE Order_StopType = 0
+	Order_STOP_LIMIT        Order_StopType = 1
+	Order_STOP_MARKET       Order_StopType = 2
+)
+
+var Order_StopType_name = map[int32]string{
+	0: "DEFAULT_STOP_TYPE",
+	1: "STOP_LIMIT",
+	2: "STOP_MARKET",
+}
+
+var Order_StopType_value = map[string]int32{
+	"DEFAULT_STOP_TYPE": 0,
+	"STOP_LIMIT":        1,
+	"STOP_MARKET":       2,
+}
+
+func (x Order_StopType) String() string {
+	return proto.EnumName(Order_StopType_name, int32(x))
+}
+
+func (Order_StopType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_3544f9578582e495, []int{0, 4}
+}
+
+type MarketProtection_Reference int32
+
+const (
+	MarketProtection_LAST_PRICE MarketProtection_Reference = 0
+	MarketProtection_BEST_PRICE MarketProtection_Reference = 1
+)
+
+var MarketProtection_Reference_name = map[int32]string{
+	0: "LAST_PRICE",
+	1: "BEST_PRICE",
+}
+
+var MarketProtection_Reference_value = map[string]int32{
+	"LAST_PRICE": 0,
+	"BEST_PRICE": 1,
+}
+
+func (x MarketProtection_Reference) String() string {
+	return proto.EnumName(MarketProtection_Reference_name, int32(x))
+}
+
+func (MarketProtection_Reference) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_3544f9578582e495, []int{10, 0}
+}
+
+type Matcher_Algorithm int32
+
+const (
+	Matcher_FIFO               Matcher_Algorithm = 0
+	Matcher_PRO_RATA           Matcher_Algorithm = 1
+	Matcher_PRO_RATA_TOP_ORDER Matcher_Algorithm = 2
+)
+
+var Matcher_Algorithm_name = map[int32]string{
+	0: "FIFO",
+	1: "PRO_RATA",
+	2: "PRO_RATA_TOP_ORDER",
+}
+
+var Matcher_Algorithm_value = map[string]int32{
+	"FIFO":               0,
+	"PRO_RATA":           1,
+	"PRO_RATA_TOP_ORDER": 2,
+}
+
+func (x Matcher_Algorithm) String() string {
+	return proto.EnumName(Matcher_Algorithm_name, int32(x))
+}
+
+func (Matcher_Algorithm) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_3544f9578582e495, []int{12, 0}
+}
+
+type SubscribeTradesRequest_SlowSubscriberPolicy int32
+
+const (
+	SubscribeTradesRequest_DISCONNECT SubscribeTradesRequest_SlowSubscriberPolicy = 0
+	SubscribeTradesRequest_DROP       SubscribeTradesRequest_SlowSubscriberPolicy = 1
+)
+
+var SubscribeTradesRequest_SlowSubscriberPolicy_name = map[int32]string{
+	0: "DISCONNECT",
+	1: "DROP",
+}
+
+var SubscribeTradesRequest_SlowSubscriberPolicy_value = map[string]int32{
+	"DISCONNECT": 0,
+	"DROP":       1,
+}
+
+func (x SubscribeTradesRequest_SlowSubscriberPolicy) String() string {
+	return proto.EnumName(SubscribeTradesRequest_SlowSubscriberPolicy_name, int32(x))
+}
+
+func (SubscribeTradesRequest_SlowSubscriberPolicy) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_3544f9578582e495, []int{32, 0}
+}
+
+type OrderEvent_Type int32
+
+const (
+	OrderEvent_ADD     OrderEvent_Type = 0
+	OrderEvent_MODIFY  OrderEvent_Type = 1
+	OrderEvent_DELETE  OrderEvent_Type = 2
+	OrderEvent_EXECUTE OrderEvent_Type = 3
+)
+
+var OrderEvent_Type_name = map[int32]string{
+	0: "ADD",
+	1: "MODIFY",
+	2: "DELETE",
+	3: "EXECUTE",
+}
+
+var OrderEvent_Type_value = map[string]int32{
+	"ADD":     0,
+	"MODIFY":  1,
+	"DELETE":  2,
+	"EXECUTE": 3,
+}
+
+func (x OrderEvent_Type) String() string {
+	return proto.EnumName(OrderEvent_Type_name, int32(x))
+}
+
+func (OrderEvent_Type) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_3544f9578582e495, []int{37, 0}
+}
+
 type Order struct {
-	Id                   uint64      `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Price                string      `protobuf:"bytes,2,opt,name=price,proto3" json:"price,omitempty"`
-	Quantity             string      `protobuf:"bytes,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
-	Side                 Order_Side  `protobuf:"varint,4,opt,name=side,proto3,enum=oceanbook.Order_Side" json:"side,omitempty"`
-	Symbol               string      `protobuf:"bytes,5,opt,name=symbol,proto3" json:"symbol,omitempty"`
-	State                Order_State `protobuf:"varint,6,opt,name=state,proto3,enum=oceanbook.Order_State" json:"state,omitempty"`
-	StopPrice            string      `protobuf:"bytes,7,opt,name=stop_price,json=stopPrice,proto3" json:"stop_price,omitempty"`
-	ImmediateOrCancel    bool        `protobuf:"varint,8,opt,name=immediate_or_cancel,json=immediateOrCancel,proto3" json:"immediate_or_cancel,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
-	XXX_unrecognized     []byte      `json:"-"`
-	XXX_sizecache        int32       `json:"-"`
+	Id                   uint64                    `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Price                string                    `protobuf:"bytes,2,opt,name=price,proto3" json:"price,omitempty"`
+	Quantity             string                    `protobuf:"bytes,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Side                 Order_Side                `protobuf:"varint,4,opt,name=side,proto3,enum=oceanbook.Order_Side" json:"side,omitempty"`
+	Symbol               string                    `protobuf:"bytes,5,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	State                Order_State               `protobuf:"varint,6,opt,name=state,proto3,enum=oceanbook.Order_State" json:"state,omitempty"`
+	StopPrice            string                    `protobuf:"bytes,7,opt,name=stop_price,json=stopPrice,proto3" json:"stop_price,omitempty"`
+	ImmediateOrCancel    bool                      `protobuf:"varint,8,opt,name=immediate_or_cancel,json=immediateOrCancel,proto3" json:"immediate_or_cancel,omitempty"`
+	FillOrKill           bool                      `protobuf:"varint,9,opt,name=fill_or_kill,json=fillOrKill,proto3" json:"fill_or_kill,omitempty"`
+	PostOnly             bool                      `protobuf:"varint,10,opt,name=post_only,json=postOnly,proto3" json:"post_only,omitempty"`
+	PostOnlySlide        bool                      `protobuf:"varint,11,opt,name=post_only_slide,json=postOnlySlide,proto3" json:"post_only_slide,omitempty"`
+	DisplayQuantity      string                    `protobuf:"bytes,12,opt,name=display_quantity,json=displayQuantity,proto3" json:"display_quantity,omitempty"`
+	OwnerId              uint64                    `protobuf:"varint,13,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+	SelfTradePrevention  Order_SelfTradePrevention `protobuf:"varint,14,opt,name=self_trade_prevention,json=selfTradePrevention,proto3,enum=oceanbook.Order_SelfTradePrevention" json:"self_trade_prevention,omitempty"`
+	ExpiresAt            *timestamp.Timestamp      `protobuf:"bytes,15,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	Trigger              Order_Trigger             `protobuf:"varint,16,opt,name=trigger,proto3,enum=oceanbook.Order_Trigger" json:"trigger,omitempty"`
+	StopType             Order_StopType            `protobuf:"varint,17,opt,name=stop_type,json=stopType,proto3,enum=oceanbook.Order_StopType" json:"stop_type,omitempty"`
+	TrailingAmount       string                    `protobuf:"bytes,18,opt,name=trailing_amount,json=trailingAmount,proto3" json:"trailing_amount,omitempty"`
+	TrailingPercent      string                    `protobuf:"bytes,19,opt,name=trailing_percent,json=trailingPercent,proto3" json:"trailing_percent,omitempty"`
+	GroupId              uint64                    `protobuf:"varint,20,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	QuoteQuantity        string                    `protobuf:"bytes,21,opt,name=quote_quantity,json=quoteQuantity,proto3" json:"quote_quantity,omitempty"`
+	FilledQuantity       string                    `protobuf:"bytes,22,opt,name=filled_quantity,json=filledQuantity,proto3" json:"filled_quantity,omitempty"`
+	RemainingQuantity    string                    `protobuf:"bytes,23,opt,name=remaining_quantity,json=remainingQuantity,proto3" json:"remaining_quantity,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
+	XXX_unrecognized     []byte                    `json:"-"`
+	XXX_sizecache        int32                     `json:"-"`
 }
 
 func (m *Order) Reset()         { *m = Order{} }
@@ -171,6 +431,111 @@ func (m *Order) GetImmediateOrCancel() bool {
 	return false
 }
 
+func (m *Order) GetFillOrKill() bool {
+	if m != nil {
+		return m.FillOrKill
+	}
+	return false
+}
+
+func (m *Order) GetPostOnly() bool {
+	if m != nil {
+		return m.PostOnly
+	}
+	return false
+}
+
+func (m *Order) GetPostOnlySlide() bool {
+	if m != nil {
+		return m.PostOnlySlide
+	}
+	return false
+}
+
+func (m *Order) GetDisplayQuantity() string {
+	if m != nil {
+		return m.DisplayQuantity
+	}
+	return ""
+}
+
+func (m *Order) GetOwnerId() uint64 {
+	if m != nil {
+		return m.OwnerId
+	}
+	return 0
+}
+
+func (m *Order) GetSelfTradePrevention() Order_SelfTradePrevention {
+	if m != nil {
+		return m.SelfTradePrevention
+	}
+	return Order_CANCEL_NEWEST
+}
+
+func (m *Order) GetExpiresAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return nil
+}
+
+func (m *Order) GetTrigger() Order_Trigger {
+	if m != nil {
+		return m.Trigger
+	}
+	return Order_DEFAULT_TRIGGER
+}
+
+func (m *Order) GetStopType() Order_StopType {
+	if m != nil {
+		return m.StopType
+	}
+	return Order_DEFAULT_STOP_TYPE
+}
+
+func (m *Order) GetTrailingAmount() string {
+	if m != nil {
+		return m.TrailingAmount
+	}
+	return ""
+}
+
+func (m *Order) GetTrailingPercent() string {
+	if m != nil {
+		return m.TrailingPercent
+	}
+	return ""
+}
+
+func (m *Order) GetGroupId() uint64 {
+	if m != nil {
+		return m.GroupId
+	}
+	return 0
+}
+
+func (m *Order) GetQuoteQuantity() string {
+	if m != nil {
+		return m.QuoteQuantity
+	}
+	return ""
+}
+
+func (m *Order) GetFilledQuantity() string {
+	if m != nil {
+		return m.FilledQuantity
+	}
+	return ""
+}
+
+func (m *Order) GetRemainingQuantity() string {
+	if m != nil {
+		return m.RemainingQuantity
+	}
+	return ""
+}
+
 type Trade struct {
 	Id                   uint64               `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
 	Symbol               string               `protobuf:"bytes,2,opt,name=symbol,proto3" json:"symbol,omitempty"`
@@ -179,6 +544,8 @@ type Trade struct {
 	TakerId              uint64               `protobuf:"varint,5,opt,name=taker_id,json=takerId,proto3" json:"taker_id,omitempty"`
 	MakerId              uint64               `protobuf:"varint,6,opt,name=maker_id,json=makerId,proto3" json:"maker_id,omitempty"`
 	CreatedAt            *timestamp.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	TakerOwnerId         uint64               `protobuf:"varint,8,opt,name=taker_owner_id,json=takerOwnerId,proto3" json:"taker_owner_id,omitempty"`
+	MakerOwnerId         uint64               `protobuf:"varint,9,opt,name=maker_owner_id,json=makerOwnerId,proto3" json:"maker_owner_id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
 	XXX_unrecognized     []byte               `json:"-"`
 	XXX_sizecache        int32                `json:"-"`
@@ -258,24 +625,105 @@ func (m *Trade) GetCreatedAt() *timestamp.Timestamp {
 	return nil
 }
 
+func (m *Trade) GetTakerOwnerId() uint64 {
+	if m != nil {
+		return m.TakerOwnerId
+	}
+	return 0
+}
+
+func (m *Trade) GetMakerOwnerId() uint64 {
+	if m != nil {
+		return m.MakerOwnerId
+	}
+	return 0
+}
+
+type ExecutionReport struct {
+	Trade                *Trade   `protobuf:"bytes,1,opt,name=trade,proto3" json:"trade,omitempty"`
+	Order                *Order   `protobuf:"bytes,2,opt,name=order,proto3" json:"order,omitempty"`
+	Reason               string   `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ExecutionReport) Reset()         { *m = ExecutionReport{} }
+func (m *ExecutionReport) String() string { return proto.CompactTextString(m) }
+func (*ExecutionReport) ProtoMessage()    {}
+func (*ExecutionReport) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3544f9578582e495, []int{2}
+}
+
+func (m *ExecutionReport) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExecutionReport.Unmarshal(m, b)
+}
+func (m *ExecutionReport) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExecutionReport.Marshal(b, m, deterministic)
+}
+func (m *ExecutionReport) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExecutionReport.Merge(m, src)
+}
+func (m *ExecutionReport) XXX_Size() int {
+	return xxx_messageInfo_ExecutionReport.Size(m)
+}
+func (m *ExecutionReport) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExecutionReport.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExecutionReport proto.InternalMessageInfo
+
+func (m *ExecutionReport) GetTrade() *Trade {
+	if m != nil {
+		return m.Trade
+	}
+	return nil
+}
+
+func (m *ExecutionReport) GetOrder() *Order {
+	if m != nil {
+		return m.Order
+	}
+	return nil
+}
+
+func (m *ExecutionReport) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
 type InsertOrderRequest struct {
-	Id                   uint64     `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Price                string     `protobuf:"bytes,2,opt,name=price,proto3" json:"price,omitempty"`
-	Quantity             string     `protobuf:"bytes,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
-	Side                 Order_Side `protobuf:"varint,4,opt,name=side,proto3,enum=oceanbook.Order_Side" json:"side,omitempty"`
-	Symbol               string     `protobuf:"bytes,5,opt,name=symbol,proto3" json:"symbol,omitempty"`
-	StopPrice            string     `protobuf:"bytes,6,opt,name=stop_price,json=stopPrice,proto3" json:"stop_price,omitempty"`
-	ImmediateOrCancel    bool       `protobuf:"varint,7,opt,name=immediate_or_cancel,json=immediateOrCancel,proto3" json:"immediate_or_cancel,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
-	XXX_unrecognized     []byte     `json:"-"`
-	XXX_sizecache        int32      `json:"-"`
+	Id                   uint64                    `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Price                string                    `protobuf:"bytes,2,opt,name=price,proto3" json:"price,omitempty"`
+	Quantity             string                    `protobuf:"bytes,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Side                 Order_Side                `protobuf:"varint,4,opt,name=side,proto3,enum=oceanbook.Order_Side" json:"side,omitempty"`
+	Symbol               string                    `protobuf:"bytes,5,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	StopPrice            string                    `protobuf:"bytes,6,opt,name=stop_price,json=stopPrice,proto3" json:"stop_price,omitempty"`
+	ImmediateOrCancel    bool                      `protobuf:"varint,7,opt,name=immediate_or_cancel,json=immediateOrCancel,proto3" json:"immediate_or_cancel,omitempty"`
+	FillOrKill           bool                      `protobuf:"varint,8,opt,name=fill_or_kill,json=fillOrKill,proto3" json:"fill_or_kill,omitempty"`
+	PostOnly             bool                      `protobuf:"varint,9,opt,name=post_only,json=postOnly,proto3" json:"post_only,omitempty"`
+	PostOnlySlide        bool                      `protobuf:"varint,10,opt,name=post_only_slide,json=postOnlySlide,proto3" json:"post_only_slide,omitempty"`
+	DisplayQuantity      string                    `protobuf:"bytes,11,opt,name=display_quantity,json=displayQuantity,proto3" json:"display_quantity,omitempty"`
+	OwnerId              uint64                    `protobuf:"varint,12,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+	SelfTradePrevention  Order_SelfTradePrevention `protobuf:"varint,13,opt,name=self_trade_prevention,json=selfTradePrevention,proto3,enum=oceanbook.Order_SelfTradePrevention" json:"self_trade_prevention,omitempty"`
+	ExpiresAt            *timestamp.Timestamp      `protobuf:"bytes,14,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	Trigger              Order_Trigger             `protobuf:"varint,15,opt,name=trigger,proto3,enum=oceanbook.Order_Trigger" json:"trigger,omitempty"`
+	StopType             Order_StopType            `protobuf:"varint,16,opt,name=stop_type,json=stopType,proto3,enum=oceanbook.Order_StopType" json:"stop_type,omitempty"`
+	TrailingAmount       string                    `protobuf:"bytes,17,opt,name=trailing_amount,json=trailingAmount,proto3" json:"trailing_amount,omitempty"`
+	TrailingPercent      string                    `protobuf:"bytes,18,opt,name=trailing_percent,json=trailingPercent,proto3" json:"trailing_percent,omitempty"`
+	QuoteQuantity        string                    `protobuf:"bytes,19,opt,name=quote_quantity,json=quoteQuantity,proto3" json:"quote_quantity,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
+	XXX_unrecognized     []byte                    `json:"-"`
+	XXX_sizecache        int32                     `json:"-"`
 }
 
 func (m *InsertOrderRequest) Reset()         { *m = InsertOrderRequest{} }
 func (m *InsertOrderRequest) String() string { return proto.CompactTextString(m) }
 func (*InsertOrderRequest) ProtoMessage()    {}
 func (*InsertOrderRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3544f9578582e495, []int{2}
+	return fileDescriptor_3544f9578582e495, []int{3}
 }
 
 func (m *InsertOrderRequest) XXX_Unmarshal(b []byte) error {
@@ -345,365 +793,2207 @@ func (m *InsertOrderRequest) GetImmediateOrCancel() bool {
 	return false
 }
 
-type CancelOrderRequest struct {
-	OrderId              uint64   `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
-	Symbol               string   `protobuf:"bytes,2,opt,name=symbol,proto3" json:"symbol,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
-}
-
-func (m *CancelOrderRequest) Reset()         { *m = CancelOrderRequest{} }
-func (m *CancelOrderRequest) String() string { return proto.CompactTextString(m) }
-func (*CancelOrderRequest) ProtoMessage()    {}
-func (*CancelOrderRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3544f9578582e495, []int{3}
+func (m *InsertOrderRequest) GetFillOrKill() bool {
+	if m != nil {
+		return m.FillOrKill
+	}
+	return false
 }
 
-func (m *CancelOrderRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_CancelOrderRequest.Unmarshal(m, b)
-}
-func (m *CancelOrderRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_CancelOrderRequest.Marshal(b, m, deterministic)
-}
-func (m *CancelOrderRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CancelOrderRequest.Merge(m, src)
-}
-func (m *CancelOrderRequest) XXX_Size() int {
-	return xxx_messageInfo_CancelOrderRequest.Size(m)
-}
-func (m *CancelOrderRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_CancelOrderRequest.DiscardUnknown(m)
+func (m *InsertOrderRequest) GetPostOnly() bool {
+	if m != nil {
+		return m.PostOnly
+	}
+	return false
 }
 
-var xxx_messageInfo_CancelOrderRequest proto.InternalMessageInfo
-
-func (m *CancelOrderRequest) GetOrderId() uint64 {
+func (m *InsertOrderRequest) GetPostOnlySlide() bool {
 	if m != nil {
-		return m.OrderId
+		return m.PostOnlySlide
 	}
-	return 0
+	return false
 }
 
-func (m *CancelOrderRequest) GetSymbol() string {
+func (m *InsertOrderRequest) GetDisplayQuantity() string {
 	if m != nil {
-		return m.Symbol
+		return m.DisplayQuantity
 	}
 	return ""
 }
 
-type CancelOrderResponse struct {
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *InsertOrderRequest) GetOwnerId() uint64 {
+	if m != nil {
+		return m.OwnerId
+	}
+	return 0
 }
 
-func (m *CancelOrderResponse) Reset()         { *m = CancelOrderResponse{} }
-func (m *CancelOrderResponse) String() string { return proto.CompactTextString(m) }
-func (*CancelOrderResponse) ProtoMessage()    {}
-func (*CancelOrderResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3544f9578582e495, []int{4}
+func (m *InsertOrderRequest) GetSelfTradePrevention() Order_SelfTradePrevention {
+	if m != nil {
+		return m.SelfTradePrevention
+	}
+	return Order_CANCEL_NEWEST
 }
 
-func (m *CancelOrderResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_CancelOrderResponse.Unmarshal(m, b)
+func (m *InsertOrderRequest) GetExpiresAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return nil
 }
-func (m *CancelOrderResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_CancelOrderResponse.Marshal(b, m, deterministic)
+
+func (m *InsertOrderRequest) GetTrigger() Order_Trigger {
+	if m != nil {
+		return m.Trigger
+	}
+	return Order_DEFAULT_TRIGGER
 }
-func (m *CancelOrderResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CancelOrderResponse.Merge(m, src)
+
+func (m *InsertOrderRequest) GetStopType() Order_StopType {
+	if m != nil {
+		return m.StopType
+	}
+	return Order_DEFAULT_STOP_TYPE
 }
-func (m *CancelOrderResponse) XXX_Size() int {
-	return xxx_messageInfo_CancelOrderResponse.Size(m)
+
+func (m *InsertOrderRequest) GetTrailingAmount() string {
+	if m != nil {
+		return m.TrailingAmount
+	}
+	return ""
 }
-func (m *CancelOrderResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_CancelOrderResponse.DiscardUnknown(m)
+
+func (m *InsertOrderRequest) GetTrailingPercent() string {
+	if m != nil {
+		return m.TrailingPercent
+	}
+	return ""
 }
 
-var xxx_messageInfo_CancelOrderResponse proto.InternalMessageInfo
+func (m *InsertOrderRequest) GetQuoteQuantity() string {
+	if m != nil {
+		return m.QuoteQuantity
+	}
+	return ""
+}
 
-type NewOrderBookRequest struct {
-	Symbol               string   `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+type InsertOCOOrderRequest struct {
+	Symbol               string              `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	GroupId              uint64              `protobuf:"varint,2,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	First                *InsertOrderRequest `protobuf:"bytes,3,opt,name=first,proto3" json:"first,omitempty"`
+	Second               *InsertOrderRequest `protobuf:"bytes,4,opt,name=second,proto3" json:"second,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
 }
 
-func (m *NewOrderBookRequest) Reset()         { *m = NewOrderBookRequest{} }
-func (m *NewOrderBookRequest) String() string { return proto.CompactTextString(m) }
-func (*NewOrderBookRequest) ProtoMessage()    {}
-func (*NewOrderBookRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3544f9578582e495, []int{5}
+func (m *InsertOCOOrderRequest) Reset()         { *m = InsertOCOOrderRequest{} }
+func (m *InsertOCOOrderRequest) String() string { return proto.CompactTextString(m) }
+func (*InsertOCOOrderRequest) ProtoMessage()    {}
+func (*InsertOCOOrderRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3544f9578582e495, []int{4}
 }
 
-func (m *NewOrderBookRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_NewOrderBookRequest.Unmarshal(m, b)
+func (m *InsertOCOOrderRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_InsertOCOOrderRequest.Unmarshal(m, b)
 }
-func (m *NewOrderBookRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_NewOrderBookRequest.Marshal(b, m, deterministic)
+func (m *InsertOCOOrderRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_InsertOCOOrderRequest.Marshal(b, m, deterministic)
 }
-func (m *NewOrderBookRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_NewOrderBookRequest.Merge(m, src)
+func (m *InsertOCOOrderRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InsertOCOOrderRequest.Merge(m, src)
 }
-func (m *NewOrderBookRequest) XXX_Size() int {
-	return xxx_messageInfo_NewOrderBookRequest.Size(m)
+func (m *InsertOCOOrderRequest) XXX_Size() int {
+	return xxx_messageInfo_InsertOCOOrderRequest.Size(m)
 }
-func (m *NewOrderBookRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_NewOrderBookRequest.DiscardUnknown(m)
+func (m *InsertOCOOrderRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_InsertOCOOrderRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_NewOrderBookRequest proto.InternalMessageInfo
+var xxx_messageInfo_InsertOCOOrderRequest proto.InternalMessageInfo
 
-func (m *NewOrderBookRequest) GetSymbol() string {
+func (m *InsertOCOOrderRequest) GetSymbol() string {
 	if m != nil {
 		return m.Symbol
 	}
 	return ""
 }
 
-type NewOrderBookResponse struct {
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *InsertOCOOrderRequest) GetGroupId() uint64 {
+	if m != nil {
+		return m.GroupId
+	}
+	return 0
 }
 
-func (m *NewOrderBookResponse) Reset()         { *m = NewOrderBookResponse{} }
-func (m *NewOrderBookResponse) String() string { return proto.CompactTextString(m) }
-func (*NewOrderBookResponse) ProtoMessage()    {}
-func (*NewOrderBookResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3544f9578582e495, []int{6}
+func (m *InsertOCOOrderRequest) GetFirst() *InsertOrderRequest {
+	if m != nil {
+		return m.First
+	}
+	return nil
 }
 
-func (m *NewOrderBookResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_NewOrderBookResponse.Unmarshal(m, b)
-}
-func (m *NewOrderBookResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_NewOrderBookResponse.Marshal(b, m, deterministic)
-}
-func (m *NewOrderBookResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_NewOrderBookResponse.Merge(m, src)
-}
-func (m *NewOrderBookResponse) XXX_Size() int {
-	return xxx_messageInfo_NewOrderBookResponse.Size(m)
-}
-func (m *NewOrderBookResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_NewOrderBookResponse.DiscardUnknown(m)
+func (m *InsertOCOOrderRequest) GetSecond() *InsertOrderRequest {
+	if m != nil {
+		return m.Second
+	}
+	return nil
 }
 
-var xxx_messageInfo_NewOrderBookResponse proto.InternalMessageInfo
-
-type GetDepthRequest struct {
-	Symbol               string   `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+type AmendOrderRequest struct {
+	OrderId              uint64   `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Symbol               string   `protobuf:"bytes,2,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Price                string   `protobuf:"bytes,3,opt,name=price,proto3" json:"price,omitempty"`
+	Quantity             string   `protobuf:"bytes,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *GetDepthRequest) Reset()         { *m = GetDepthRequest{} }
-func (m *GetDepthRequest) String() string { return proto.CompactTextString(m) }
-func (*GetDepthRequest) ProtoMessage()    {}
-func (*GetDepthRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3544f9578582e495, []int{7}
+func (m *AmendOrderRequest) Reset()         { *m = AmendOrderRequest{} }
+func (m *AmendOrderRequest) String() string { return proto.CompactTextString(m) }
+func (*AmendOrderRequest) ProtoMessage()    {}
+func (*AmendOrderRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3544f9578582e495, []int{5}
 }
 
-func (m *GetDepthRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetDepthRequest.Unmarshal(m, b)
+func (m *AmendOrderRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AmendOrderRequest.Unmarshal(m, b)
 }
-func (m *GetDepthRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetDepthRequest.Marshal(b, m, deterministic)
+func (m *AmendOrderRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AmendOrderRequest.Marshal(b, m, deterministic)
 }
-func (m *GetDepthRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetDepthRequest.Merge(m, src)
+func (m *AmendOrderRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AmendOrderRequest.Merge(m, src)
 }
-func (m *GetDepthRequest) XXX_Size() int {
-	return xxx_messageInfo_GetDepthRequest.Size(m)
+func (m *AmendOrderRequest) XXX_Size() int {
+	return xxx_messageInfo_AmendOrderRequest.Size(m)
 }
-func (m *GetDepthRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetDepthRequest.DiscardUnknown(m)
+func (m *AmendOrderRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AmendOrderRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetDepthRequest proto.InternalMessageInfo
+var xxx_messageInfo_AmendOrderRequest proto.InternalMessageInfo
 
-func (m *GetDepthRequest) GetSymbol() string {
+func (m *AmendOrderRequest) GetOrderId() uint64 {
+	if m != nil {
+		return m.OrderId
+	}
+	return 0
+}
+
+func (m *AmendOrderRequest) GetSymbol() string {
 	if m != nil {
 		return m.Symbol
 	}
 	return ""
 }
 
-type PriceLevel struct {
-	Price                string   `protobuf:"bytes,1,opt,name=price,proto3" json:"price,omitempty"`
-	Quantity             string   `protobuf:"bytes,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
-	OrdersCount          uint64   `protobuf:"varint,3,opt,name=orders_count,json=ordersCount,proto3" json:"orders_count,omitempty"`
+func (m *AmendOrderRequest) GetPrice() string {
+	if m != nil {
+		return m.Price
+	}
+	return ""
+}
+
+func (m *AmendOrderRequest) GetQuantity() string {
+	if m != nil {
+		return m.Quantity
+	}
+	return ""
+}
+
+type CancelOrderRequest struct {
+	OrderId              uint64   `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Symbol               string   `protobuf:"bytes,2,opt,name=symbol,proto3" json:"symbol,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *PriceLevel) Reset()         { *m = PriceLevel{} }
-func (m *PriceLevel) String() string { return proto.CompactTextString(m) }
-func (*PriceLevel) ProtoMessage()    {}
-func (*PriceLevel) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3544f9578582e495, []int{8}
+func (m *CancelOrderRequest) Reset()         { *m = CancelOrderRequest{} }
+func (m *CancelOrderRequest) String() string { return proto.CompactTextString(m) }
+func (*CancelOrderRequest) ProtoMessage()    {}
+func (*CancelOrderRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3544f9578582e495, []int{6}
 }
 
-func (m *PriceLevel) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_PriceLevel.Unmarshal(m, b)
+func (m *CancelOrderRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CancelOrderRequest.Unmarshal(m, b)
 }
-func (m *PriceLevel) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_PriceLevel.Marshal(b, m, deterministic)
+func (m *CancelOrderRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CancelOrderRequest.Marshal(b, m, deterministic)
 }
-func (m *PriceLevel) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_PriceLevel.Merge(m, src)
+func (m *CancelOrderRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CancelOrderRequest.Merge(m, src)
 }
-func (m *PriceLevel) XXX_Size() int {
-	return xxx_messageInfo_PriceLevel.Size(m)
+func (m *CancelOrderRequest) XXX_Size() int {
+	return xxx_messageInfo_CancelOrderRequest.Size(m)
 }
-func (m *PriceLevel) XXX_DiscardUnknown() {
-	xxx_messageInfo_PriceLevel.DiscardUnknown(m)
+func (m *CancelOrderRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CancelOrderRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_PriceLevel proto.InternalMessageInfo
+var xxx_messageInfo_CancelOrderRequest proto.InternalMessageInfo
 
-func (m *PriceLevel) GetPrice() string {
+func (m *CancelOrderRequest) GetOrderId() uint64 {
 	if m != nil {
-		return m.Price
+		return m.OrderId
 	}
-	return ""
+	return 0
 }
 
-func (m *PriceLevel) GetQuantity() string {
+func (m *CancelOrderRequest) GetSymbol() string {
 	if m != nil {
-		return m.Quantity
+		return m.Symbol
 	}
 	return ""
 }
 
-func (m *PriceLevel) GetOrdersCount() uint64 {
+type CancelOrderResponse struct {
+	Order                *Order   `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CancelOrderResponse) Reset()         { *m = CancelOrderResponse{} }
+func (m *CancelOrderResponse) String() string { return proto.CompactTextString(m) }
+func (*CancelOrderResponse) ProtoMessage()    {}
+func (*CancelOrderResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3544f9578582e495, []int{7}
+}
+
+func (m *CancelOrderResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CancelOrderResponse.Unmarshal(m, b)
+}
+func (m *CancelOrderResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CancelOrderResponse.Marshal(b, m, deterministic)
+}
+func (m *CancelOrderResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CancelOrderResponse.Merge(m, src)
+}
+func (m *CancelOrderResponse) XXX_Size() int {
+	return xxx_messageInfo_CancelOrderResponse.Size(m)
+}
+func (m *CancelOrderResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CancelOrderResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CancelOrderResponse proto.InternalMessageInfo
+
+func (m *CancelOrderResponse) GetOrder() *Order {
 	if m != nil {
-		return m.OrdersCount
+		return m.Order
 	}
-	return 0
+	return nil
 }
 
-type Depth struct {
-	Symbol               string        `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
-	Bids                 []*PriceLevel `protobuf:"bytes,2,rep,name=bids,proto3" json:"bids,omitempty"`
-	Asks                 []*PriceLevel `protobuf:"bytes,3,rep,name=asks,proto3" json:"asks,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
-	XXX_unrecognized     []byte        `json:"-"`
-	XXX_sizecache        int32         `json:"-"`
+type MassCancelRequest struct {
+	Symbol               string       `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Sides                []Order_Side `protobuf:"varint,2,rep,packed,name=sides,proto3,enum=oceanbook.Order_Side" json:"sides,omitempty"`
+	OwnerId              uint64       `protobuf:"varint,3,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+	MinPrice             string       `protobuf:"bytes,4,opt,name=min_price,json=minPrice,proto3" json:"min_price,omitempty"`
+	MaxPrice             string       `protobuf:"bytes,5,opt,name=max_price,json=maxPrice,proto3" json:"max_price,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
 }
 
-func (m *Depth) Reset()         { *m = Depth{} }
-func (m *Depth) String() string { return proto.CompactTextString(m) }
-func (*Depth) ProtoMessage()    {}
-func (*Depth) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3544f9578582e495, []int{9}
+func (m *MassCancelRequest) Reset()         { *m = MassCancelRequest{} }
+func (m *MassCancelRequest) String() string { return proto.CompactTextString(m) }
+func (*MassCancelRequest) ProtoMessage()    {}
+func (*MassCancelRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3544f9578582e495, []int{8}
 }
 
-func (m *Depth) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Depth.Unmarshal(m, b)
+func (m *MassCancelRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MassCancelRequest.Unmarshal(m, b)
 }
-func (m *Depth) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Depth.Marshal(b, m, deterministic)
+func (m *MassCancelRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MassCancelRequest.Marshal(b, m, deterministic)
 }
-func (m *Depth) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Depth.Merge(m, src)
+func (m *MassCancelRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MassCancelRequest.Merge(m, src)
 }
-func (m *Depth) XXX_Size() int {
-	return xxx_messageInfo_Depth.Size(m)
+func (m *MassCancelRequest) XXX_Size() int {
+	return xxx_messageInfo_MassCancelRequest.Size(m)
 }
-func (m *Depth) XXX_DiscardUnknown() {
-	xxx_messageInfo_Depth.DiscardUnknown(m)
+func (m *MassCancelRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_MassCancelRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Depth proto.InternalMessageInfo
+var xxx_messageInfo_MassCancelRequest proto.InternalMessageInfo
 
-func (m *Depth) GetSymbol() string {
+func (m *MassCancelRequest) GetSymbol() string {
 	if m != nil {
 		return m.Symbol
 	}
 	return ""
 }
 
-func (m *Depth) GetBids() []*PriceLevel {
+func (m *MassCancelRequest) GetSides() []Order_Side {
 	if m != nil {
-		return m.Bids
+		return m.Sides
 	}
 	return nil
 }
 
-func (m *Depth) GetAsks() []*PriceLevel {
+func (m *MassCancelRequest) GetOwnerId() uint64 {
 	if m != nil {
-		return m.Asks
+		return m.OwnerId
+	}
+	return 0
+}
+
+func (m *MassCancelRequest) GetMinPrice() string {
+	if m != nil {
+		return m.MinPrice
+	}
+	return ""
+}
+
+func (m *MassCancelRequest) GetMaxPrice() string {
+	if m != nil {
+		return m.MaxPrice
+	}
+	return ""
+}
+
+type MassCancelResponse struct {
+	OrderIds             []uint64 `protobuf:"varint,1,rep,packed,name=order_ids,json=orderIds,proto3" json:"order_ids,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MassCancelResponse) Reset()         { *m = MassCancelResponse{} }
+func (m *MassCancelResponse) String() string { return proto.CompactTextString(m) }
+func (*MassCancelResponse) ProtoMessage()    {}
+func (*MassCancelResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3544f9578582e495, []int{9}
+}
+
+func (m *MassCancelResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MassCancelResponse.Unmarshal(m, b)
+}
+func (m *MassCancelResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MassCancelResponse.Marshal(b, m, deterministic)
+}
+func (m *MassCancelResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MassCancelResponse.Merge(m, src)
+}
+func (m *MassCancelResponse) XXX_Size() int {
+	return xxx_messageInfo_MassCancelResponse.Size(m)
+}
+func (m *MassCancelResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_MassCancelResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MassCancelResponse proto.InternalMessageInfo
+
+func (m *MassCancelResponse) GetOrderIds() []uint64 {
+	if m != nil {
+		return m.OrderIds
+	}
+	return nil
+}
+
+type MarketProtection struct {
+	Deviation            string                     `protobuf:"bytes,1,opt,name=deviation,proto3" json:"deviation,omitempty"`
+	Reference            MarketProtection_Reference `protobuf:"varint,2,opt,name=reference,proto3,enum=oceanbook.MarketProtection_Reference" json:"reference,omitempty"`
+	RestRemainder        bool                       `protobuf:"varint,3,opt,name=rest_remainder,json=restRemainder,proto3" json:"rest_remainder,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                   `json:"-"`
+	XXX_unrecognized     []byte                     `json:"-"`
+	XXX_sizecache        int32                      `json:"-"`
+}
+
+func (m *MarketProtection) Reset()         { *m = MarketProtection{} }
+func (m *MarketProtection) String() string { return proto.CompactTextString(m) }
+func (*MarketProtection) ProtoMessage()    {}
+func (*MarketProtection) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3544f9578582e495, []int{10}
+}
+
+func (m *MarketProtection) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MarketProtection.Unmarshal(m, b)
+}
+func (m *MarketProtection) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MarketProtection.Marshal(b, m, deterministic)
+}
+func (m *MarketProtection) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MarketProtection.Merge(m, src)
+}
+func (m *MarketProtection) XXX_Size() int {
+	return xxx_messageInfo_MarketProtection.Size(m)
+}
+func (m *MarketProtection) XXX_DiscardUnknown() {
+	xxx_messageInfo_MarketProtection.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MarketProtection proto.InternalMessageInfo
+
+func (m *MarketProtection) GetDeviation() string {
+	if m != nil {
+		return m.Deviation
+	}
+	return ""
+}
+
+func (m *MarketProtection) GetReference() MarketProtection_Reference {
+	if m != nil {
+		return m.Reference
+	}
+	return MarketProtection_LAST_PRICE
+}
+
+func (m *MarketProtection) GetRestRemainder() bool {
+	if m != nil {
+		return m.RestRemainder
+	}
+	return false
+}
+
+type MarketSpec struct {
+	TickSize             string   `protobuf:"bytes,1,opt,name=tick_size,json=tickSize,proto3" json:"tick_size,omitempty"`
+	LotSize              string   `protobuf:"bytes,2,opt,name=lot_size,json=lotSize,proto3" json:"lot_size,omitempty"`
+	PricePrecision       uint32   `protobuf:"varint,3,opt,name=price_precision,json=pricePrecision,proto3" json:"price_precision,omitempty"`
+	QuantityPrecision    uint32   `protobuf:"varint,4,opt,name=quantity_precision,json=quantityPrecision,proto3" json:"quantity_precision,omitempty"`
+	MinQuantity          string   `protobuf:"bytes,5,opt,name=min_quantity,json=minQuantity,proto3" json:"min_quantity,omitempty"`
+	MaxQuantity          string   `protobuf:"bytes,6,opt,name=max_quantity,json=maxQuantity,proto3" json:"max_quantity,omitempty"`
+	MinNotional          string   `protobuf:"bytes,7,opt,name=min_notional,json=minNotional,proto3" json:"min_notional,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MarketSpec) Reset()         { *m = MarketSpec{} }
+func (m *MarketSpec) String() string { return proto.CompactTextString(m) }
+func (*MarketSpec) ProtoMessage()    {}
+func (*MarketSpec) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3544f9578582e495, []int{11}
+}
+
+func (m *MarketSpec) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MarketSpec.Unmarshal(m, b)
+}
+func (m *MarketSpec) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MarketSpec.Marshal(b, m, deterministic)
+}
+func (m *MarketSpec) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MarketSpec.Merge(m, src)
+}
+func (m *MarketSpec) XXX_Size() int {
+	return xxx_messageInfo_MarketSpec.Size(m)
+}
+func (m *MarketSpec) XXX_DiscardUnknown() {
+	xxx_messageInfo_MarketSpec.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MarketSpec proto.InternalMessageInfo
+
+func (m *MarketSpec) GetTickSize() string {
+	if m != nil {
+		return m.TickSize
+	}
+	return ""
+}
+
+func (m *MarketSpec) GetLotSize() string {
+	if m != nil {
+		return m.LotSize
+	}
+	return ""
+}
+
+func (m *MarketSpec) GetPricePrecision() uint32 {
+	if m != nil {
+		return m.PricePrecision
+	}
+	return 0
+}
+
+func (m *MarketSpec) GetQuantityPrecision() uint32 {
+	if m != nil {
+		return m.QuantityPrecision
+	}
+	return 0
+}
+
+func (m *MarketSpec) GetMinQuantity() string {
+	if m != nil {
+		return m.MinQuantity
+	}
+	return ""
+}
+
+func (m *MarketSpec) GetMaxQuantity() string {
+	if m != nil {
+		return m.MaxQuantity
+	}
+	return ""
+}
+
+func (m *MarketSpec) GetMinNotional() string {
+	if m != nil {
+		return m.MinNotional
+	}
+	return ""
+}
+
+type Matcher struct {
+	Algorithm            Matcher_Algorithm `protobuf:"varint,1,opt,name=algorithm,proto3,enum=oceanbook.Matcher_Algorithm" json:"algorithm,omitempty"`
+	TopOrderCap          string            `protobuf:"bytes,2,opt,name=top_order_cap,json=topOrderCap,proto3" json:"top_order_cap,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *Matcher) Reset()         { *m = Matcher{} }
+func (m *Matcher) String() string { return proto.CompactTextString(m) }
+func (*Matcher) ProtoMessage()    {}
+func (*Matcher) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3544f9578582e495, []int{12}
+}
+
+func (m *Matcher) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Matcher.Unmarshal(m, b)
+}
+func (m *Matcher) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Matcher.Marshal(b, m, deterministic)
+}
+func (m *Matcher) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Matcher.Merge(m, src)
+}
+func (m *Matcher) XXX_Size() int {
+	return xxx_messageInfo_Matcher.Size(m)
+}
+func (m *Matcher) XXX_DiscardUnknown() {
+	xxx_messageInfo_Matcher.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Matcher proto.InternalMessageInfo
+
+func (m *Matcher) GetAlgorithm() Matcher_Algorithm {
+	if m != nil {
+		return m.Algorithm
+	}
+	return Matcher_FIFO
+}
+
+func (m *Matcher) GetTopOrderCap() string {
+	if m != nil {
+		return m.TopOrderCap
+	}
+	return ""
+}
+
+type CircuitBreaker struct {
+	Band                 string   `protobuf:"bytes,1,opt,name=band,proto3" json:"band,omitempty"`
+	WindowSeconds        int64    `protobuf:"varint,2,opt,name=window_seconds,json=windowSeconds,proto3" json:"window_seconds,omitempty"`
+	ReopenByAuction      bool     `protobuf:"varint,3,opt,name=reopen_by_auction,json=reopenByAuction,proto3" json:"reopen_by_auction,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CircuitBreaker) Reset()         { *m = CircuitBreaker{} }
+func (m *CircuitBreaker) String() string { return proto.CompactTextString(m) }
+func (*CircuitBreaker) ProtoMessage()    {}
+func (*CircuitBreaker) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3544f9578582e495, []int{13}
+}
+
+func (m *CircuitBreaker) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CircuitBreaker.Unmarshal(m, b)
+}
+func (m *CircuitBreaker) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CircuitBreaker.Marshal(b, m, deterministic)
+}
+func (m *CircuitBreaker) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CircuitBreaker.Merge(m, src)
+}
+func (m *CircuitBreaker) XXX_Size() int {
+	return xxx_messageInfo_CircuitBreaker.Size(m)
+}
+func (m *CircuitBreaker) XXX_DiscardUnknown() {
+	xxx_messageInfo_CircuitBreaker.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CircuitBreaker proto.InternalMessageInfo
+
+func (m *CircuitBreaker) GetBand() string {
+	if m != nil {
+		return m.Band
+	}
+	return ""
+}
+
+func (m *CircuitBreaker) GetWindowSeconds() int64 {
+	if m != nil {
+		return m.WindowSeconds
+	}
+	return 0
+}
+
+func (m *CircuitBreaker) GetReopenByAuction() bool {
+	if m != nil {
+		return m.ReopenByAuction
+	}
+	return false
+}
+
+type NewOrderBookRequest struct {
+	Symbol               string            `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	MarketProtection     *MarketProtection `protobuf:"bytes,2,opt,name=market_protection,json=marketProtection,proto3" json:"market_protection,omitempty"`
+	MarketSpec           *MarketSpec       `protobuf:"bytes,3,opt,name=market_spec,json=marketSpec,proto3" json:"market_spec,omitempty"`
+	Matcher              *Matcher          `protobuf:"bytes,4,opt,name=matcher,proto3" json:"matcher,omitempty"`
+	TradingState         TradingState      `protobuf:"varint,5,opt,name=trading_state,json=tradingState,proto3,enum=oceanbook.TradingState" json:"trading_state,omitempty"`
+	CircuitBreaker       *CircuitBreaker   `protobuf:"bytes,6,opt,name=circuit_breaker,json=circuitBreaker,proto3" json:"circuit_breaker,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *NewOrderBookRequest) Reset()         { *m = NewOrderBookRequest{} }
+func (m *NewOrderBookRequest) String() string { return proto.CompactTextString(m) }
+func (*NewOrderBookRequest) ProtoMessage()    {}
+func (*NewOrderBookRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3544f9578582e495, []int{14}
+}
+
+func (m *NewOrderBookRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NewOrderBookRequest.Unmarshal(m, b)
+}
+func (m *NewOrderBookRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NewOrderBookRequest.Marshal(b, m, deterministic)
+}
+func (m *NewOrderBookRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NewOrderBookRequest.Merge(m, src)
+}
+func (m *NewOrderBookRequest) XXX_Size() int {
+	return xxx_messageInfo_NewOrderBookRequest.Size(m)
+}
+func (m *NewOrderBookRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_NewOrderBookRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NewOrderBookRequest proto.InternalMessageInfo
+
+func (m *NewOrderBookRequest) GetSymbol() string {
+	if m != nil {
+		return m.Symbol
+	}
+	return ""
+}
+
+func (m *NewOrderBookRequest) GetMarketProtection() *MarketProtection {
+	if m != nil {
+		return m.MarketProtection
+	}
+	return nil
+}
+
+func (m *NewOrderBookRequest) GetMarketSpec() *MarketSpec {
+	if m != nil {
+		return m.MarketSpec
+	}
+	return nil
+}
+
+func (m *NewOrderBookRequest) GetMatcher() *Matcher {
+	if m != nil {
+		return m.Matcher
+	}
+	return nil
+}
+
+func (m *NewOrderBookRequest) GetTradingState() TradingState {
+	if m != nil {
+		return m.TradingState
+	}
+	return TradingState_CONTINUOUS
+}
+
+func (m *NewOrderBookRequest) GetCircuitBreaker() *CircuitBreaker {
+	if m != nil {
+		return m.CircuitBreaker
+	}
+	return nil
+}
+
+type NewOrderBookResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NewOrderBookResponse) Reset()         { *m = NewOrderBookResponse{} }
+func (m *NewOrderBookResponse) String() string { return proto.CompactTextString(m) }
+func (*NewOrderBookResponse) ProtoMessage()    {}
+func (*NewOrderBookResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3544f9578582e495, []int{15}
+}
+
+func (m *NewOrderBookResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NewOrderBookResponse.Unmarshal(m, b)
+}
+func (m *NewOrderBookResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NewOrderBookResponse.Marshal(b, m, deterministic)
+}
+func (m *NewOrderBookResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NewOrderBookResponse.Merge(m, src)
+}
+func (m *NewOrderBookResponse) XXX_Size() int {
+	return xxx_messageInfo_NewOrderBookResponse.Size(m)
+}
+func (m *NewOrderBookResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_NewOrderBookResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NewOrderBookResponse proto.InternalMessageInfo
+
+type SetTradingStateRequest struct {
+	Symbol               string       `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	State                TradingState `protobuf:"varint,2,opt,name=state,proto3,enum=oceanbook.TradingState" json:"state,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *SetTradingStateRequest) Reset()         { *m = SetTradingStateRequest{} }
+func (m *SetTradingStateRequest) String() string { return proto.CompactTextString(m) }
+func (*SetTradingStateRequest) ProtoMessage()    {}
+func (*SetTradingStateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3544f9578582e495, []int{16}
+}
+
+func (m *SetTradingStateRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetTradingStateRequest.Unmarshal(m, b)
+}
+func (m *SetTradingStateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetTradingStateRequest.Marshal(b, m, deterministic)
+}
+func (m *SetTradingStateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetTradingStateRequest.Merge(m, src)
+}
+func (m *SetTradingStateRequest) XXX_Size() int {
+	return xxx_messageInfo_SetTradingStateRequest.Size(m)
+}
+func (m *SetTradingStateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetTradingStateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetTradingStateRequest proto.InternalMessageInfo
+
+func (m *SetTradingStateRequest) GetSymbol() string {
+	if m != nil {
+		return m.Symbol
+	}
+	return ""
+}
+
+func (m *SetTradingStateRequest) GetState() TradingState {
+	if m != nil {
+		return m.State
+	}
+	return TradingState_CONTINUOUS
+}
+
+type SetTradingStateResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetTradingStateResponse) Reset()         { *m = SetTradingStateResponse{} }
+func (m *SetTradingStateResponse) String() string { return proto.CompactTextString(m) }
+func (*SetTradingStateResponse) ProtoMessage()    {}
+func (*SetTradingStateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3544f9578582e495, []int{17}
+}
+
+func (m *SetTradingStateResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetTradingStateResponse.Unmarshal(m, b)
+}
+func (m *SetTradingStateResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetTradingStateResponse.Marshal(b, m, deterministic)
+}
+func (m *SetTradingStateResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetTradingStateResponse.Merge(m, src)
+}
+func (m *SetTradingStateResponse) XXX_Size() int {
+	return xxx_messageInfo_SetTradingStateResponse.Size(m)
+}
+func (m *SetTradingStateResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetTradingStateResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetTradingStateResponse proto.InternalMessageInfo
+
+type GetTradingStateRequest struct {
+	Symbol               string   `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetTradingStateRequest) Reset()         { *m = GetTradingStateRequest{} }
+func (m *GetTradingStateRequest) String() string { return proto.CompactTextString(m) }
+func (*GetTradingStateRequest) ProtoMessage()    {}
+func (*GetTradingStateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3544f9578582e495, []int{18}
+}
+
+func (m *GetTradingStateRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetTradingStateRequest.Unmarshal(m, b)
+}
+func (m *GetTradingStateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetTradingStateRequest.Marshal(b, m, deterministic)
+}
+func (m *GetTradingStateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetTradingStateRequest.Merge(m, src)
+}
+func (m *GetTradingStateRequest) XXX_Size() int {
+	return xxx_messageInfo_GetTradingStateRequest.Size(m)
+}
+func (m *GetTradingStateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetTradingStateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetTradingStateRequest proto.InternalMessageInfo
+
+func (m *GetTradingStateRequest) GetSymbol() string {
+	if m != nil {
+		return m.Symbol
+	}
+	return ""
+}
+
+type GetTradingStateResponse struct {
+	State                TradingState `protobuf:"varint,1,opt,name=state,proto3,enum=oceanbook.TradingState" json:"state,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *GetTradingStateResponse) Reset()         { *m = GetTradingStateResponse{} }
+func (m *GetTradingStateResponse) String() string { return proto.CompactTextString(m) }
+func (*GetTradingStateResponse) ProtoMessage()    {}
+func (*GetTradingStateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3544f9578582e495, []int{19}
+}
+
+func (m *GetTradingStateResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetTradingStateResponse.Unmarshal(m, b)
+}
+func (m *GetTradingStateResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetTradingStateResponse.Marshal(b, m, deterministic)
+}
+func (m *GetTradingStateResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetTradingStateResponse.Merge(m, src)
+}
+func (m *GetTradingStateResponse) XXX_Size() int {
+	return xxx_messageInfo_GetTradingStateResponse.Size(m)
+}
+func (m *GetTradingStateResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetTradingStateResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetTradingStateResponse proto.InternalMessageInfo
+
+func (m *GetTradingStateResponse) GetState() TradingState {
+	if m != nil {
+		return m.State
+	}
+	return TradingState_CONTINUOUS
+}
+
+type StartAuctionRequest struct {
+	Symbol               string   `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StartAuctionRequest) Reset()         { *m = StartAuctionRequest{} }
+func (m *StartAuctionRequest) String() string { return proto.CompactTextString(m) }
+func (*StartAuctionRequest) ProtoMessage()    {}
+func (*StartAuctionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3544f9578582e495, []int{20}
+}
+
+func (m *StartAuctionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StartAuctionRequest.Unmarshal(m, b)
+}
+func (m *StartAuctionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StartAuctionRequest.Marshal(b, m, deterministic)
+}
+func (m *StartAuctionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StartAuctionRequest.Merge(m, src)
+}
+func (m *StartAuctionRequest) XXX_Size() int {
+	return xxx_messageInfo_StartAuctionRequest.Size(m)
+}
+func (m *StartAuctionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StartAuctionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StartAuctionRequest proto.InternalMessageInfo
+
+func (m *StartAuctionRequest) GetSymbol() string {
+	if m != nil {
+		return m.Symbol
+	}
+	return ""
+}
+
+type StartAuctionResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StartAuctionResponse) Reset()         { *m = StartAuctionResponse{} }
+func (m *StartAuctionResponse) String() string { return proto.CompactTextString(m) }
+func (*StartAuctionResponse) ProtoMessage()    {}
+func (*StartAuctionResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3544f9578582e495, []int{21}
+}
+
+func (m *StartAuctionResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StartAuctionResponse.Unmarshal(m, b)
+}
+func (m *StartAuctionResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StartAuctionResponse.Marshal(b, m, deterministic)
+}
+func (m *StartAuctionResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StartAuctionResponse.Merge(m, src)
+}
+func (m *StartAuctionResponse) XXX_Size() int {
+	return xxx_messageInfo_StartAuctionResponse.Size(m)
+}
+func (m *StartAuctionResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_StartAuctionResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StartAuctionResponse proto.InternalMessageInfo
+
+type UncrossRequest struct {
+	Symbol               string   `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UncrossRequest) Reset()         { *m = UncrossRequest{} }
+func (m *UncrossRequest) String() string { return proto.CompactTextString(m) }
+func (*UncrossRequest) ProtoMessage()    {}
+func (*UncrossRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3544f9578582e495, []int{22}
+}
+
+func (m *UncrossRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UncrossRequest.Unmarshal(m, b)
+}
+func (m *UncrossRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UncrossRequest.Marshal(b, m, deterministic)
+}
+func (m *UncrossRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UncrossRequest.Merge(m, src)
+}
+func (m *UncrossRequest) XXX_Size() int {
+	return xxx_messageInfo_UncrossRequest.Size(m)
+}
+func (m *UncrossRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UncrossRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UncrossRequest proto.InternalMessageInfo
+
+func (m *UncrossRequest) GetSymbol() string {
+	if m != nil {
+		return m.Symbol
+	}
+	return ""
+}
+
+type GetOrderRequest struct {
+	Symbol               string   `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	OrderId              uint64   `protobuf:"varint,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetOrderRequest) Reset()         { *m = GetOrderRequest{} }
+func (m *GetOrderRequest) String() string { return proto.CompactTextString(m) }
+func (*GetOrderRequest) ProtoMessage()    {}
+func (*GetOrderRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3544f9578582e495, []int{23}
+}
+
+func (m *GetOrderRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetOrderRequest.Unmarshal(m, b)
+}
+func (m *GetOrderRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetOrderRequest.Marshal(b, m, deterministic)
+}
+func (m *GetOrderRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetOrderRequest.Merge(m, src)
+}
+func (m *GetOrderRequest) XXX_Size() int {
+	return xxx_messageInfo_GetOrderRequest.Size(m)
+}
+func (m *GetOrderRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetOrderRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetOrderRequest proto.InternalMessageInfo
+
+func (m *GetOrderRequest) GetSymbol() string {
+	if m != nil {
+		return m.Symbol
+	}
+	return ""
+}
+
+func (m *GetOrderRequest) GetOrderId() uint64 {
+	if m != nil {
+		return m.OrderId
+	}
+	return 0
+}
+
+type ListOpenOrdersRequest struct {
+	Symbol               string       `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Sides                []Order_Side `protobuf:"varint,2,rep,packed,name=sides,proto3,enum=oceanbook.Order_Side" json:"sides,omitempty"`
+	OwnerId              uint64       `protobuf:"varint,3,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+	PageToken            uint64       `protobuf:"varint,4,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	PageSize             uint32       `protobuf:"varint,5,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *ListOpenOrdersRequest) Reset()         { *m = ListOpenOrdersRequest{} }
+func (m *ListOpenOrdersRequest) String() string { return proto.CompactTextString(m) }
+func (*ListOpenOrdersRequest) ProtoMessage()    {}
+func (*ListOpenOrdersRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3544f9578582e495, []int{24}
+}
+
+func (m *ListOpenOrdersRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListOpenOrdersRequest.Unmarshal(m, b)
+}
+func (m *ListOpenOrdersRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListOpenOrdersRequest.Marshal(b, m, deterministic)
+}
+func (m *ListOpenOrdersRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListOpenOrdersRequest.Merge(m, src)
+}
+func (m *ListOpenOrdersRequest) XXX_Size() int {
+	return xxx_messageInfo_ListOpenOrdersRequest.Size(m)
+}
+func (m *ListOpenOrdersRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListOpenOrdersRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListOpenOrdersRequest proto.InternalMessageInfo
+
+func (m *ListOpenOrdersRequest) GetSymbol() string {
+	if m != nil {
+		return m.Symbol
+	}
+	return ""
+}
+
+func (m *ListOpenOrdersRequest) GetSides() []Order_Side {
+	if m != nil {
+		return m.Sides
+	}
+	return nil
+}
+
+func (m *ListOpenOrdersRequest) GetOwnerId() uint64 {
+	if m != nil {
+		return m.OwnerId
+	}
+	return 0
+}
+
+func (m *ListOpenOrdersRequest) GetPageToken() uint64 {
+	if m != nil {
+		return m.PageToken
+	}
+	return 0
+}
+
+func (m *ListOpenOrdersRequest) GetPageSize() uint32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+type ListOpenOrdersResponse struct {
+	Orders               []*Order `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
+	NextPageToken        uint64   `protobuf:"varint,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListOpenOrdersResponse) Reset()         { *m = ListOpenOrdersResponse{} }
+func (m *ListOpenOrdersResponse) String() string { return proto.CompactTextString(m) }
+func (*ListOpenOrdersResponse) ProtoMessage()    {}
+func (*ListOpenOrdersResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3544f9578582e495, []int{25}
+}
+
+func (m *ListOpenOrdersResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListOpenOrdersResponse.Unmarshal(m, b)
+}
+func (m *ListOpenOrdersResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListOpenOrdersResponse.Marshal(b, m, deterministic)
+}
+func (m *ListOpenOrdersResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListOpenOrdersResponse.Merge(m, src)
+}
+func (m *ListOpenOrdersResponse) XXX_Size() int {
+	return xxx_messageInfo_ListOpenOrdersResponse.Size(m)
+}
+func (m *ListOpenOrdersResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListOpenOrdersResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListOpenOrdersResponse proto.InternalMessageInfo
+
+func (m *ListOpenOrdersResponse) GetOrders() []*Order {
+	if m != nil {
+		return m.Orders
+	}
+	return nil
+}
+
+func (m *ListOpenOrdersResponse) GetNextPageToken() uint64 {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return 0
+}
+
+type GetDepthRequest struct {
+	Symbol               string   `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Increment            string   `protobuf:"bytes,2,opt,name=increment,proto3" json:"increment,omitempty"`
+	Limit                uint32   `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetDepthRequest) Reset()         { *m = GetDepthRequest{} }
+func (m *GetDepthRequest) String() string { return proto.CompactTextString(m) }
+func (*GetDepthRequest) ProtoMessage()    {}
+func (*GetDepthRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3544f9578582e495, []int{26}
+}
+
+func (m *GetDepthRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetDepthRequest.Unmarshal(m, b)
+}
+func (m *GetDepthRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetDepthRequest.Marshal(b, m, deterministic)
+}
+func (m *GetDepthRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetDepthRequest.Merge(m, src)
+}
+func (m *GetDepthRequest) XXX_Size() int {
+	return xxx_messageInfo_GetDepthRequest.Size(m)
+}
+func (m *GetDepthRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetDepthRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetDepthRequest proto.InternalMessageInfo
+
+func (m *GetDepthRequest) GetSymbol() string {
+	if m != nil {
+		return m.Symbol
+	}
+	return ""
+}
+
+func (m *GetDepthRequest) GetIncrement() string {
+	if m != nil {
+		return m.Increment
+	}
+	return ""
+}
+
+func (m *GetDepthRequest) GetLimit() uint32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type PriceLevel struct {
+	Price                string   `protobuf:"bytes,1,opt,name=price,proto3" json:"price,omitempty"`
+	Quantity             string   `protobuf:"bytes,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	OrdersCount          uint64   `protobuf:"varint,3,opt,name=orders_count,json=ordersCount,proto3" json:"orders_count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PriceLevel) Reset()         { *m = PriceLevel{} }
+func (m *PriceLevel) String() string { return proto.CompactTextString(m) }
+func (*PriceLevel) ProtoMessage()    {}
+func (*PriceLevel) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3544f9578582e495, []int{27}
+}
+
+func (m *PriceLevel) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PriceLevel.Unmarshal(m, b)
+}
+func (m *PriceLevel) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PriceLevel.Marshal(b, m, deterministic)
+}
+func (m *PriceLevel) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PriceLevel.Merge(m, src)
+}
+func (m *PriceLevel) XXX_Size() int {
+	return xxx_messageInfo_PriceLevel.Size(m)
+}
+func (m *PriceLevel) XXX_DiscardUnknown() {
+	xxx_messageInfo_PriceLevel.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PriceLevel proto.InternalMessageInfo
+
+func (m *PriceLevel) GetPrice() string {
+	if m != nil {
+		return m.Price
+	}
+	return ""
+}
+
+func (m *PriceLevel) GetQuantity() string {
+	if m != nil {
+		return m.Quantity
+	}
+	return ""
+}
+
+func (m *PriceLevel) GetOrdersCount() uint64 {
+	if m != nil {
+		return m.OrdersCount
+	}
+	return 0
+}
+
+type Depth struct {
+	Symbol               string        `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Bids                 []*PriceLevel `protobuf:"bytes,2,rep,name=bids,proto3" json:"bids,omitempty"`
+	Asks                 []*PriceLevel `protobuf:"bytes,3,rep,name=asks,proto3" json:"asks,omitempty"`
+	Sequence             uint64        `protobuf:"varint,4,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *Depth) Reset()         { *m = Depth{} }
+func (m *Depth) String() string { return proto.CompactTextString(m) }
+func (*Depth) ProtoMessage()    {}
+func (*Depth) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3544f9578582e495, []int{28}
+}
+
+func (m *Depth) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Depth.Unmarshal(m, b)
+}
+func (m *Depth) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Depth.Marshal(b, m, deterministic)
+}
+func (m *Depth) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Depth.Merge(m, src)
+}
+func (m *Depth) XXX_Size() int {
+	return xxx_messageInfo_Depth.Size(m)
+}
+func (m *Depth) XXX_DiscardUnknown() {
+	xxx_messageInfo_Depth.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Depth proto.InternalMessageInfo
+
+func (m *Depth) GetSymbol() string {
+	if m != nil {
+		return m.Symbol
+	}
+	return ""
+}
+
+func (m *Depth) GetBids() []*PriceLevel {
+	if m != nil {
+		return m.Bids
+	}
+	return nil
+}
+
+func (m *Depth) GetAsks() []*PriceLevel {
+	if m != nil {
+		return m.Asks
+	}
+	return nil
+}
+
+func (m *Depth) GetSequence() uint64 {
+	if m != nil {
+		return m.Sequence
+	}
+	return 0
+}
+
+type SubscribeDepthRequest struct {
+	Symbol               string   `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SubscribeDepthRequest) Reset()         { *m = SubscribeDepthRequest{} }
+func (m *SubscribeDepthRequest) String() string { return proto.CompactTextString(m) }
+func (*SubscribeDepthRequest) ProtoMessage()    {}
+func (*SubscribeDepthRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3544f9578582e495, []int{29}
+}
+
+func (m *SubscribeDepthRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SubscribeDepthRequest.Unmarshal(m, b)
+}
+func (m *SubscribeDepthRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SubscribeDepthRequest.Marshal(b, m, deterministic)
+}
+func (m *SubscribeDepthRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubscribeDepthRequest.Merge(m, src)
+}
+func (m *SubscribeDepthRequest) XXX_Size() int {
+	return xxx_messageInfo_SubscribeDepthRequest.Size(m)
+}
+func (m *SubscribeDepthRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubscribeDepthRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SubscribeDepthRequest proto.InternalMessageInfo
+
+func (m *SubscribeDepthRequest) GetSymbol() string {
+	if m != nil {
+		return m.Symbol
+	}
+	return ""
+}
+
+type DepthUpdate struct {
+	Sequence             uint64      `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	Side                 Order_Side  `protobuf:"varint,2,opt,name=side,proto3,enum=oceanbook.Order_Side" json:"side,omitempty"`
+	PriceLevel           *PriceLevel `protobuf:"bytes,3,opt,name=price_level,json=priceLevel,proto3" json:"price_level,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *DepthUpdate) Reset()         { *m = DepthUpdate{} }
+func (m *DepthUpdate) String() string { return proto.CompactTextString(m) }
+func (*DepthUpdate) ProtoMessage()    {}
+func (*DepthUpdate) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3544f9578582e495, []int{30}
+}
+
+func (m *DepthUpdate) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DepthUpdate.Unmarshal(m, b)
+}
+func (m *DepthUpdate) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DepthUpdate.Marshal(b, m, deterministic)
+}
+func (m *DepthUpdate) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DepthUpdate.Merge(m, src)
+}
+func (m *DepthUpdate) XXX_Size() int {
+	return xxx_messageInfo_DepthUpdate.Size(m)
+}
+func (m *DepthUpdate) XXX_DiscardUnknown() {
+	xxx_messageInfo_DepthUpdate.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DepthUpdate proto.InternalMessageInfo
+
+func (m *DepthUpdate) GetSequence() uint64 {
+	if m != nil {
+		return m.Sequence
+	}
+	return 0
+}
+
+func (m *DepthUpdate) GetSide() Order_Side {
+	if m != nil {
+		return m.Side
+	}
+	return Order_ASK
+}
+
+func (m *DepthUpdate) GetPriceLevel() *PriceLevel {
+	if m != nil {
+		return m.PriceLevel
+	}
+	return nil
+}
+
+type DepthEvent struct {
+	// Types that are valid to be assigned to Event:
+	//	*DepthEvent_Snapshot
+	//	*DepthEvent_Update
+	Event                isDepthEvent_Event `protobuf_oneof:"event"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *DepthEvent) Reset()         { *m = DepthEvent{} }
+func (m *DepthEvent) String() string { return proto.CompactTextString(m) }
+func (*DepthEvent) ProtoMessage()    {}
+func (*DepthEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3544f9578582e495, []int{31}
+}
+
+func (m *DepthEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DepthEvent.Unmarshal(m, b)
+}
+func (m *DepthEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DepthEvent.Marshal(b, m, deterministic)
+}
+func (m *DepthEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DepthEvent.Merge(m, src)
+}
+func (m *DepthEvent) XXX_Size() int {
+	return xxx_messageInfo_DepthEvent.Size(m)
+}
+func (m *DepthEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_DepthEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DepthEvent proto.InternalMessageInfo
+
+type isDepthEvent_Event interface {
+	isDepthEvent_Event()
+}
+
+type DepthEvent_Snapshot struct {
+	Snapshot *Depth `protobuf:"bytes,1,opt,name=snapshot,proto3,oneof"`
+}
+
+type DepthEvent_Update struct {
+	Update *DepthUpdate `protobuf:"bytes,2,opt,name=update,proto3,oneof"`
+}
+
+func (*DepthEvent_Snapshot) isDepthEvent_Event() {}
+
+func (*DepthEvent_Update) isDepthEvent_Event() {}
+
+func (m *DepthEvent) GetEvent() isDepthEvent_Event {
+	if m != nil {
+		return m.Event
+	}
+	return nil
+}
+
+func (m *DepthEvent) GetSnapshot() *Depth {
+	if x, ok := m.GetEvent().(*DepthEvent_Snapshot); ok {
+		return x.Snapshot
+	}
+	return nil
+}
+
+func (m *DepthEvent) GetUpdate() *DepthUpdate {
+	if x, ok := m.GetEvent().(*DepthEvent_Update); ok {
+		return x.Update
+	}
+	return nil
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*DepthEvent) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*DepthEvent_Snapshot)(nil),
+		(*DepthEvent_Update)(nil),
+	}
+}
+
+type SubscribeTradesRequest struct {
+	Symbol               string                                      `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	SlowSubscriberPolicy SubscribeTradesRequest_SlowSubscriberPolicy `protobuf:"varint,2,opt,name=slow_subscriber_policy,json=slowSubscriberPolicy,proto3,enum=oceanbook.SubscribeTradesRequest_SlowSubscriberPolicy" json:"slow_subscriber_policy,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                                    `json:"-"`
+	XXX_unrecognized     []byte                                      `json:"-"`
+	XXX_sizecache        int32                                       `json:"-"`
+}
+
+func (m *SubscribeTradesRequest) Reset()         { *m = SubscribeTradesRequest{} }
+func (m *SubscribeTradesRequest) String() string { return proto.CompactTextString(m) }
+func (*SubscribeTradesRequest) ProtoMessage()    {}
+func (*SubscribeTradesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3544f9578582e495, []int{32}
+}
+
+func (m *SubscribeTradesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SubscribeTradesRequest.Unmarshal(m, b)
+}
+func (m *SubscribeTradesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SubscribeTradesRequest.Marshal(b, m, deterministic)
+}
+func (m *SubscribeTradesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubscribeTradesRequest.Merge(m, src)
+}
+func (m *SubscribeTradesRequest) XXX_Size() int {
+	return xxx_messageInfo_SubscribeTradesRequest.Size(m)
+}
+func (m *SubscribeTradesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubscribeTradesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SubscribeTradesRequest proto.InternalMessageInfo
+
+func (m *SubscribeTradesRequest) GetSymbol() string {
+	if m != nil {
+		return m.Symbol
+	}
+	return ""
+}
+
+func (m *SubscribeTradesRequest) GetSlowSubscriberPolicy() SubscribeTradesRequest_SlowSubscriberPolicy {
+	if m != nil {
+		return m.SlowSubscriberPolicy
+	}
+	return SubscribeTradesRequest_DISCONNECT
+}
+
+type SubscribeOrderBookRequest struct {
+	Symbol               string   `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SubscribeOrderBookRequest) Reset()         { *m = SubscribeOrderBookRequest{} }
+func (m *SubscribeOrderBookRequest) String() string { return proto.CompactTextString(m) }
+func (*SubscribeOrderBookRequest) ProtoMessage()    {}
+func (*SubscribeOrderBookRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3544f9578582e495, []int{33}
+}
+
+func (m *SubscribeOrderBookRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SubscribeOrderBookRequest.Unmarshal(m, b)
+}
+func (m *SubscribeOrderBookRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SubscribeOrderBookRequest.Marshal(b, m, deterministic)
+}
+func (m *SubscribeOrderBookRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubscribeOrderBookRequest.Merge(m, src)
+}
+func (m *SubscribeOrderBookRequest) XXX_Size() int {
+	return xxx_messageInfo_SubscribeOrderBookRequest.Size(m)
+}
+func (m *SubscribeOrderBookRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubscribeOrderBookRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SubscribeOrderBookRequest proto.InternalMessageInfo
+
+func (m *SubscribeOrderBookRequest) GetSymbol() string {
+	if m != nil {
+		return m.Symbol
+	}
+	return ""
+}
+
+type SubscribeExecutionReportsRequest struct {
+	Symbol               string   `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	OwnerId              uint64   `protobuf:"varint,2,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SubscribeExecutionReportsRequest) Reset()         { *m = SubscribeExecutionReportsRequest{} }
+func (m *SubscribeExecutionReportsRequest) String() string { return proto.CompactTextString(m) }
+func (*SubscribeExecutionReportsRequest) ProtoMessage()    {}
+func (*SubscribeExecutionReportsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3544f9578582e495, []int{34}
+}
+
+func (m *SubscribeExecutionReportsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SubscribeExecutionReportsRequest.Unmarshal(m, b)
+}
+func (m *SubscribeExecutionReportsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SubscribeExecutionReportsRequest.Marshal(b, m, deterministic)
+}
+func (m *SubscribeExecutionReportsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubscribeExecutionReportsRequest.Merge(m, src)
+}
+func (m *SubscribeExecutionReportsRequest) XXX_Size() int {
+	return xxx_messageInfo_SubscribeExecutionReportsRequest.Size(m)
+}
+func (m *SubscribeExecutionReportsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubscribeExecutionReportsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SubscribeExecutionReportsRequest proto.InternalMessageInfo
+
+func (m *SubscribeExecutionReportsRequest) GetSymbol() string {
+	if m != nil {
+		return m.Symbol
+	}
+	return ""
+}
+
+func (m *SubscribeExecutionReportsRequest) GetOwnerId() uint64 {
+	if m != nil {
+		return m.OwnerId
+	}
+	return 0
+}
+
+type RestingOrder struct {
+	OrderId              uint64   `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Price                string   `protobuf:"bytes,2,opt,name=price,proto3" json:"price,omitempty"`
+	Quantity             string   `protobuf:"bytes,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Position             uint32   `protobuf:"varint,4,opt,name=position,proto3" json:"position,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RestingOrder) Reset()         { *m = RestingOrder{} }
+func (m *RestingOrder) String() string { return proto.CompactTextString(m) }
+func (*RestingOrder) ProtoMessage()    {}
+func (*RestingOrder) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3544f9578582e495, []int{35}
+}
+
+func (m *RestingOrder) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RestingOrder.Unmarshal(m, b)
+}
+func (m *RestingOrder) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RestingOrder.Marshal(b, m, deterministic)
+}
+func (m *RestingOrder) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestingOrder.Merge(m, src)
+}
+func (m *RestingOrder) XXX_Size() int {
+	return xxx_messageInfo_RestingOrder.Size(m)
+}
+func (m *RestingOrder) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestingOrder.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RestingOrder proto.InternalMessageInfo
+
+func (m *RestingOrder) GetOrderId() uint64 {
+	if m != nil {
+		return m.OrderId
+	}
+	return 0
+}
+
+func (m *RestingOrder) GetPrice() string {
+	if m != nil {
+		return m.Price
+	}
+	return ""
+}
+
+func (m *RestingOrder) GetQuantity() string {
+	if m != nil {
+		return m.Quantity
+	}
+	return ""
+}
+
+func (m *RestingOrder) GetPosition() uint32 {
+	if m != nil {
+		return m.Position
+	}
+	return 0
+}
+
+type OrderBookSnapshot struct {
+	Symbol               string          `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Sequence             uint64          `protobuf:"varint,2,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	Bids                 []*RestingOrder `protobuf:"bytes,3,rep,name=bids,proto3" json:"bids,omitempty"`
+	Asks                 []*RestingOrder `protobuf:"bytes,4,rep,name=asks,proto3" json:"asks,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *OrderBookSnapshot) Reset()         { *m = OrderBookSnapshot{} }
+func (m *OrderBookSnapshot) String() string { return proto.CompactTextString(m) }
+func (*OrderBookSnapshot) ProtoMessage()    {}
+func (*OrderBookSnapshot) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3544f9578582e495, []int{36}
+}
+
+func (m *OrderBookSnapshot) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OrderBookSnapshot.Unmarshal(m, b)
+}
+func (m *OrderBookSnapshot) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OrderBookSnapshot.Marshal(b, m, deterministic)
+}
+func (m *OrderBookSnapshot) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OrderBookSnapshot.Merge(m, src)
+}
+func (m *OrderBookSnapshot) XXX_Size() int {
+	return xxx_messageInfo_OrderBookSnapshot.Size(m)
+}
+func (m *OrderBookSnapshot) XXX_DiscardUnknown() {
+	xxx_messageInfo_OrderBookSnapshot.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OrderBookSnapshot proto.InternalMessageInfo
+
+func (m *OrderBookSnapshot) GetSymbol() string {
+	if m != nil {
+		return m.Symbol
+	}
+	return ""
+}
+
+func (m *OrderBookSnapshot) GetSequence() uint64 {
+	if m != nil {
+		return m.Sequence
+	}
+	return 0
+}
+
+func (m *OrderBookSnapshot) GetBids() []*RestingOrder {
+	if m != nil {
+		return m.Bids
+	}
+	return nil
+}
+
+func (m *OrderBookSnapshot) GetAsks() []*RestingOrder {
+	if m != nil {
+		return m.Asks
+	}
+	return nil
+}
+
+type OrderEvent struct {
+	Sequence             uint64          `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	Type                 OrderEvent_Type `protobuf:"varint,2,opt,name=type,proto3,enum=oceanbook.OrderEvent_Type" json:"type,omitempty"`
+	OrderId              uint64          `protobuf:"varint,3,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Side                 Order_Side      `protobuf:"varint,4,opt,name=side,proto3,enum=oceanbook.Order_Side" json:"side,omitempty"`
+	Price                string          `protobuf:"bytes,5,opt,name=price,proto3" json:"price,omitempty"`
+	Quantity             string          `protobuf:"bytes,6,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	ExecutedQuantity     string          `protobuf:"bytes,7,opt,name=executed_quantity,json=executedQuantity,proto3" json:"executed_quantity,omitempty"`
+	Position             uint32          `protobuf:"varint,8,opt,name=position,proto3" json:"position,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *OrderEvent) Reset()         { *m = OrderEvent{} }
+func (m *OrderEvent) String() string { return proto.CompactTextString(m) }
+func (*OrderEvent) ProtoMessage()    {}
+func (*OrderEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3544f9578582e495, []int{37}
+}
+
+func (m *OrderEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OrderEvent.Unmarshal(m, b)
+}
+func (m *OrderEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OrderEvent.Marshal(b, m, deterministic)
+}
+func (m *OrderEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OrderEvent.Merge(m, src)
+}
+func (m *OrderEvent) XXX_Size() int {
+	return xxx_messageInfo_OrderEvent.Size(m)
+}
+func (m *OrderEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_OrderEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OrderEvent proto.InternalMessageInfo
+
+func (m *OrderEvent) GetSequence() uint64 {
+	if m != nil {
+		return m.Sequence
+	}
+	return 0
+}
+
+func (m *OrderEvent) GetType() OrderEvent_Type {
+	if m != nil {
+		return m.Type
+	}
+	return OrderEvent_ADD
+}
+
+func (m *OrderEvent) GetOrderId() uint64 {
+	if m != nil {
+		return m.OrderId
+	}
+	return 0
+}
+
+func (m *OrderEvent) GetSide() Order_Side {
+	if m != nil {
+		return m.Side
+	}
+	return Order_ASK
+}
+
+func (m *OrderEvent) GetPrice() string {
+	if m != nil {
+		return m.Price
+	}
+	return ""
+}
+
+func (m *OrderEvent) GetQuantity() string {
+	if m != nil {
+		return m.Quantity
+	}
+	return ""
+}
+
+func (m *OrderEvent) GetExecutedQuantity() string {
+	if m != nil {
+		return m.ExecutedQuantity
+	}
+	return ""
+}
+
+func (m *OrderEvent) GetPosition() uint32 {
+	if m != nil {
+		return m.Position
+	}
+	return 0
+}
+
+type OrderBookEvent struct {
+	// Types that are valid to be assigned to Event:
+	//	*OrderBookEvent_Snapshot
+	//	*OrderBookEvent_Update
+	Event                isOrderBookEvent_Event `protobuf_oneof:"event"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
+}
+
+func (m *OrderBookEvent) Reset()         { *m = OrderBookEvent{} }
+func (m *OrderBookEvent) String() string { return proto.CompactTextString(m) }
+func (*OrderBookEvent) ProtoMessage()    {}
+func (*OrderBookEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3544f9578582e495, []int{38}
+}
+
+func (m *OrderBookEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OrderBookEvent.Unmarshal(m, b)
+}
+func (m *OrderBookEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OrderBookEvent.Marshal(b, m, deterministic)
+}
+func (m *OrderBookEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OrderBookEvent.Merge(m, src)
+}
+func (m *OrderBookEvent) XXX_Size() int {
+	return xxx_messageInfo_OrderBookEvent.Size(m)
+}
+func (m *OrderBookEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_OrderBookEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OrderBookEvent proto.InternalMessageInfo
+
+type isOrderBookEvent_Event interface {
+	isOrderBookEvent_Event()
+}
+
+type OrderBookEvent_Snapshot struct {
+	Snapshot *OrderBookSnapshot `protobuf:"bytes,1,opt,name=snapshot,proto3,oneof"`
+}
+
+type OrderBookEvent_Update struct {
+	Update *OrderEvent `protobuf:"bytes,2,opt,name=update,proto3,oneof"`
+}
+
+func (*OrderBookEvent_Snapshot) isOrderBookEvent_Event() {}
+
+func (*OrderBookEvent_Update) isOrderBookEvent_Event() {}
+
+func (m *OrderBookEvent) GetEvent() isOrderBookEvent_Event {
+	if m != nil {
+		return m.Event
+	}
+	return nil
+}
+
+func (m *OrderBookEvent) GetSnapshot() *OrderBookSnapshot {
+	if x, ok := m.GetEvent().(*OrderBookEvent_Snapshot); ok {
+		return x.Snapshot
+	}
+	return nil
+}
+
+func (m *OrderBookEvent) GetUpdate() *OrderEvent {
+	if x, ok := m.GetEvent().(*OrderBookEvent_Update); ok {
+		return x.Update
+	}
+	return nil
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*OrderBookEvent) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*OrderBookEvent_Snapshot)(nil),
+		(*OrderBookEvent_Update)(nil),
 	}
-	return nil
 }
 
 func init() {
+	proto.RegisterEnum("oceanbook.TradingState", TradingState_name, TradingState_value)
 	proto.RegisterEnum("oceanbook.Order_Side", Order_Side_name, Order_Side_value)
 	proto.RegisterEnum("oceanbook.Order_State", Order_State_name, Order_State_value)
+	proto.RegisterEnum("oceanbook.Order_SelfTradePrevention", Order_SelfTradePrevention_name, Order_SelfTradePrevention_value)
+	proto.RegisterEnum("oceanbook.Order_Trigger", Order_Trigger_name, Order_Trigger_value)
+	proto.RegisterEnum("oceanbook.Order_StopType", Order_StopType_name, Order_StopType_value)
+	proto.RegisterEnum("oceanbook.MarketProtection_Reference", MarketProtection_Reference_name, MarketProtection_Reference_value)
+	proto.RegisterEnum("oceanbook.Matcher_Algorithm", Matcher_Algorithm_name, Matcher_Algorithm_value)
+	proto.RegisterEnum("oceanbook.SubscribeTradesRequest_SlowSubscriberPolicy", SubscribeTradesRequest_SlowSubscriberPolicy_name, SubscribeTradesRequest_SlowSubscriberPolicy_value)
+	proto.RegisterEnum("oceanbook.OrderEvent_Type", OrderEvent_Type_name, OrderEvent_Type_value)
 	proto.RegisterType((*Order)(nil), "oceanbook.Order")
 	proto.RegisterType((*Trade)(nil), "oceanbook.Trade")
+	proto.RegisterType((*ExecutionReport)(nil), "oceanbook.ExecutionReport")
 	proto.RegisterType((*InsertOrderRequest)(nil), "oceanbook.InsertOrderRequest")
+	proto.RegisterType((*InsertOCOOrderRequest)(nil), "oceanbook.InsertOCOOrderRequest")
+	proto.RegisterType((*AmendOrderRequest)(nil), "oceanbook.AmendOrderRequest")
 	proto.RegisterType((*CancelOrderRequest)(nil), "oceanbook.CancelOrderRequest")
 	proto.RegisterType((*CancelOrderResponse)(nil), "oceanbook.CancelOrderResponse")
+	proto.RegisterType((*MassCancelRequest)(nil), "oceanbook.MassCancelRequest")
+	proto.RegisterType((*MassCancelResponse)(nil), "oceanbook.MassCancelResponse")
+	proto.RegisterType((*MarketProtection)(nil), "oceanbook.MarketProtection")
+	proto.RegisterType((*MarketSpec)(nil), "oceanbook.MarketSpec")
+	proto.RegisterType((*Matcher)(nil), "oceanbook.Matcher")
+	proto.RegisterType((*CircuitBreaker)(nil), "oceanbook.CircuitBreaker")
 	proto.RegisterType((*NewOrderBookRequest)(nil), "oceanbook.NewOrderBookRequest")
 	proto.RegisterType((*NewOrderBookResponse)(nil), "oceanbook.NewOrderBookResponse")
+	proto.RegisterType((*SetTradingStateRequest)(nil), "oceanbook.SetTradingStateRequest")
+	proto.RegisterType((*SetTradingStateResponse)(nil), "oceanbook.SetTradingStateResponse")
+	proto.RegisterType((*GetTradingStateRequest)(nil), "oceanbook.GetTradingStateRequest")
+	proto.RegisterType((*GetTradingStateResponse)(nil), "oceanbook.GetTradingStateResponse")
+	proto.RegisterType((*StartAuctionRequest)(nil), "oceanbook.StartAuctionRequest")
+	proto.RegisterType((*StartAuctionResponse)(nil), "oceanbook.StartAuctionResponse")
+	proto.RegisterType((*UncrossRequest)(nil), "oceanbook.UncrossRequest")
+	proto.RegisterType((*GetOrderRequest)(nil), "oceanbook.GetOrderRequest")
+	proto.RegisterType((*ListOpenOrdersRequest)(nil), "oceanbook.ListOpenOrdersRequest")
+	proto.RegisterType((*ListOpenOrdersResponse)(nil), "oceanbook.ListOpenOrdersResponse")
 	proto.RegisterType((*GetDepthRequest)(nil), "oceanbook.GetDepthRequest")
 	proto.RegisterType((*PriceLevel)(nil), "oceanbook.PriceLevel")
 	proto.RegisterType((*Depth)(nil), "oceanbook.Depth")
+	proto.RegisterType((*SubscribeDepthRequest)(nil), "oceanbook.SubscribeDepthRequest")
+	proto.RegisterType((*DepthUpdate)(nil), "oceanbook.DepthUpdate")
+	proto.RegisterType((*DepthEvent)(nil), "oceanbook.DepthEvent")
+	proto.RegisterType((*SubscribeTradesRequest)(nil), "oceanbook.SubscribeTradesRequest")
+	proto.RegisterType((*SubscribeOrderBookRequest)(nil), "oceanbook.SubscribeOrderBookRequest")
+	proto.RegisterType((*SubscribeExecutionReportsRequest)(nil), "oceanbook.SubscribeExecutionReportsRequest")
+	proto.RegisterType((*RestingOrder)(nil), "oceanbook.RestingOrder")
+	proto.RegisterType((*OrderBookSnapshot)(nil), "oceanbook.OrderBookSnapshot")
+	proto.RegisterType((*OrderEvent)(nil), "oceanbook.OrderEvent")
+	proto.RegisterType((*OrderBookEvent)(nil), "oceanbook.OrderBookEvent")
 }
 
 func init() { proto.RegisterFile("oceanbook.proto", fileDescriptor_3544f9578582e495) }
 
 var fileDescriptor_3544f9578582e495 = []byte{
-	// 687 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x55, 0xcb, 0x6e, 0xd3, 0x40,
-	0x14, 0x8d, 0x9d, 0xf7, 0x4d, 0x69, 0xc3, 0xf4, 0x21, 0x37, 0x52, 0x4b, 0xf0, 0x2a, 0x95, 0xa8,
-	0x83, 0xca, 0x8a, 0x87, 0x90, 0x9a, 0xa4, 0x54, 0x11, 0x55, 0x5a, 0xdc, 0xae, 0xd8, 0x44, 0x63,
-	0xfb, 0x92, 0x5a, 0x89, 0x3d, 0xae, 0x67, 0x52, 0xd4, 0x9f, 0xe2, 0x63, 0x10, 0x1f, 0xc1, 0x67,
-	0x20, 0x8f, 0x13, 0xc7, 0x6e, 0x30, 0xb0, 0x64, 0xe7, 0x3b, 0xe7, 0xf8, 0xcc, 0xbd, 0xe7, 0x9e,
-	0xc4, 0xb0, 0xc5, 0x6c, 0xa4, 0xbe, 0xc5, 0xd8, 0xd4, 0x08, 0x42, 0x26, 0x18, 0xa9, 0x27, 0x07,
-	0xad, 0xb7, 0x13, 0x57, 0xdc, 0xce, 0x2d, 0xc3, 0x66, 0x5e, 0x77, 0xc2, 0x66, 0xd4, 0x9f, 0x74,
-	0x25, 0xc7, 0x9a, 0x7f, 0xe9, 0x06, 0xe2, 0x21, 0x40, 0xde, 0x15, 0xae, 0x87, 0x5c, 0x50, 0x2f,
-	0x58, 0x3d, 0xc5, 0x3a, 0xfa, 0x0f, 0x15, 0xca, 0x97, 0xa1, 0x83, 0x21, 0xd9, 0x04, 0xd5, 0x75,
-	0x34, 0xa5, 0xad, 0x74, 0x4a, 0xa6, 0xea, 0x3a, 0x64, 0x07, 0xca, 0x41, 0xe8, 0xda, 0xa8, 0xa9,
-	0x6d, 0xa5, 0x53, 0x37, 0xe3, 0x82, 0xb4, 0xa0, 0x76, 0x37, 0xa7, 0xbe, 0x70, 0xc5, 0x83, 0x56,
-	0x94, 0x40, 0x52, 0x93, 0x23, 0x28, 0x71, 0xd7, 0x41, 0xad, 0xd4, 0x56, 0x3a, 0x9b, 0x27, 0xbb,
-	0xc6, 0xaa, 0x67, 0x79, 0x83, 0x71, 0xed, 0x3a, 0x68, 0x4a, 0x0a, 0xd9, 0x83, 0x0a, 0x7f, 0xf0,
-	0x2c, 0x36, 0xd3, 0xca, 0x52, 0x64, 0x51, 0x91, 0x17, 0x50, 0xe6, 0x82, 0x0a, 0xd4, 0x2a, 0x52,
-	0x63, 0x6f, 0x5d, 0x23, 0x42, 0xcd, 0x98, 0x44, 0x0e, 0x00, 0xb8, 0x60, 0xc1, 0x38, 0xee, 0xb3,
-	0x2a, 0x95, 0xea, 0xd1, 0xc9, 0x95, 0xec, 0xd5, 0x80, 0x6d, 0xd7, 0xf3, 0xd0, 0x71, 0xa9, 0xc0,
-	0x31, 0x0b, 0xc7, 0x36, 0xf5, 0x6d, 0x9c, 0x69, 0xb5, 0xb6, 0xd2, 0xa9, 0x99, 0x4f, 0x13, 0xe8,
-	0x32, 0xec, 0x4b, 0x40, 0xd7, 0xa0, 0x14, 0xb5, 0x48, 0xaa, 0x50, 0x3c, 0xbd, 0xfe, 0xd8, 0x2c,
-	0x44, 0x0f, 0xbd, 0xe1, 0xa0, 0xa9, 0xe8, 0x5d, 0x28, 0xcb, 0x8b, 0x49, 0x03, 0xaa, 0x57, 0x67,
-	0xa3, 0xc1, 0x70, 0x74, 0xde, 0x2c, 0x10, 0x80, 0xca, 0x87, 0xe1, 0xc5, 0xc5, 0xd9, 0xa0, 0xa9,
-	0x90, 0x27, 0x50, 0xef, 0x9f, 0x8e, 0xfa, 0x67, 0xb2, 0x54, 0xf5, 0xef, 0x0a, 0x94, 0x6f, 0x42,
-	0xea, 0xe0, 0x9a, 0xad, 0xab, 0xc9, 0xd5, 0xcc, 0xe4, 0x89, 0xdd, 0xc5, 0x3c, 0xbb, 0x4b, 0x8f,
-	0xec, 0xde, 0x87, 0x9a, 0xa0, 0x53, 0x0c, 0xc7, 0xae, 0x23, 0x5d, 0x2c, 0x99, 0x55, 0x59, 0x0f,
-	0x9d, 0x08, 0xf2, 0x96, 0x50, 0x25, 0x86, 0xbc, 0x05, 0xf4, 0x1a, 0xc0, 0x0e, 0x91, 0x0a, 0x74,
-	0xc6, 0x54, 0x48, 0xcf, 0x1a, 0x27, 0x2d, 0x63, 0xc2, 0xd8, 0x64, 0x86, 0xc6, 0x32, 0x37, 0xc6,
-	0xcd, 0x32, 0x26, 0x66, 0x7d, 0xc1, 0x3e, 0x15, 0xfa, 0x4f, 0x05, 0xc8, 0xd0, 0xe7, 0x18, 0x0a,
-	0xb9, 0x0b, 0x13, 0xef, 0xe6, 0xc8, 0xc5, 0xff, 0x11, 0x9c, 0x6c, 0x14, 0x2a, 0xff, 0x18, 0x85,
-	0x6a, 0x5e, 0x14, 0xce, 0x81, 0xc4, 0x4f, 0x99, 0x49, 0xf7, 0xa1, 0xc6, 0xa2, 0x7a, 0x9c, 0xcc,
-	0x5b, 0x95, 0xf5, 0x30, 0x77, 0xad, 0xfa, 0x2e, 0x6c, 0x67, 0x84, 0x78, 0xc0, 0x7c, 0x8e, 0xfa,
-	0x31, 0x6c, 0x8f, 0xf0, 0xab, 0x3c, 0xeb, 0x31, 0x36, 0x5d, 0x5e, 0xb0, 0x52, 0x51, 0x32, 0x2a,
-	0x7b, 0xb0, 0x93, 0xa5, 0x2f, 0x64, 0x8e, 0x60, 0xeb, 0x1c, 0xc5, 0x00, 0x03, 0x71, 0xfb, 0x37,
-	0x09, 0x0a, 0x20, 0xad, 0xb8, 0xc0, 0x7b, 0x4c, 0xa5, 0x4d, 0xc9, 0xdb, 0x91, 0xfa, 0x68, 0x47,
-	0xcf, 0x61, 0x43, 0xce, 0xca, 0xc7, 0x36, 0x9b, 0xfb, 0x42, 0xee, 0xb0, 0x64, 0x36, 0xe2, 0xb3,
-	0x7e, 0x74, 0xa4, 0xcf, 0xa1, 0x2c, 0x5b, 0xc9, 0xeb, 0x21, 0xda, 0xb3, 0xe5, 0x3a, 0x5c, 0x53,
-	0xdb, 0xc5, 0x4e, 0x23, 0xb3, 0xe7, 0x55, 0x6b, 0xa6, 0xa4, 0x44, 0x54, 0xca, 0xa7, 0x5c, 0x2b,
-	0xfe, 0x91, 0x1a, 0x51, 0x4e, 0xbe, 0xa9, 0x50, 0xbf, 0x5c, 0xc2, 0xe4, 0x13, 0x6c, 0xa4, 0xad,
-	0x22, 0x87, 0xa9, 0x57, 0x7f, 0x63, 0x79, 0xeb, 0x59, 0x2e, 0xbe, 0xf0, 0xb8, 0x40, 0x7a, 0xd0,
-	0x48, 0xc5, 0x9e, 0x1c, 0xa4, 0xde, 0x58, 0xff, 0x39, 0xb4, 0x9a, 0x29, 0x58, 0xfe, 0x05, 0xe8,
-	0x85, 0x97, 0x0a, 0x19, 0x41, 0x23, 0x95, 0x83, 0x8c, 0xc6, 0x7a, 0xd0, 0x5a, 0x87, 0x79, 0x70,
-	0xd2, 0xd3, 0x1b, 0xa8, 0x2d, 0x37, 0x4f, 0x5a, 0x29, 0xf6, 0xa3, 0x38, 0x64, 0xba, 0x91, 0x80,
-	0x5e, 0xe8, 0xbd, 0xff, 0xfc, 0x2e, 0xf5, 0xc9, 0x70, 0x42, 0x7a, 0x8f, 0x3e, 0x72, 0xde, 0x4d,
-	0x98, 0x5d, 0x1a, 0xb8, 0xc9, 0x37, 0xe4, 0x98, 0x07, 0x68, 0xaf, 0xb0, 0xc0, 0xb2, 0x2a, 0x12,
-	0x7a, 0xf5, 0x2b, 0x00, 0x00, 0xff, 0xff, 0xa5, 0x89, 0xaf, 0xb2, 0x95, 0x06, 0x00, 0x00,
+	// 2778 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x5a, 0xcd, 0x6f, 0xe3, 0xc6,
+	0x15, 0x37, 0xf5, 0xad, 0x27, 0x5b, 0xa2, 0xc6, 0x1f, 0x2b, 0x6b, 0x77, 0x13, 0x2f, 0xb1, 0x49,
+	0x9c, 0x6c, 0x56, 0xde, 0x3a, 0xed, 0x02, 0xd9, 0xa4, 0x45, 0x65, 0x89, 0x6b, 0x2b, 0x2b, 0x4b,
+	0x5a, 0x4a, 0x46, 0xb2, 0x01, 0x0a, 0x82, 0xa6, 0xc6, 0x32, 0x6b, 0x8a, 0x64, 0xc8, 0xf1, 0xda,
+	0xce, 0xbd, 0x3d, 0xf5, 0x92, 0x5b, 0x4f, 0x05, 0x7a, 0xe9, 0xad, 0xa7, 0x02, 0xfd, 0x07, 0x7a,
+	0xea, 0xa9, 0xfd, 0x0f, 0xfa, 0xaf, 0x14, 0x33, 0x43, 0x52, 0xa4, 0x3e, 0x2c, 0xe7, 0x03, 0x45,
+	0x6f, 0x9a, 0xf7, 0x7e, 0x7c, 0xf3, 0xe6, 0x7d, 0xcf, 0x40, 0x50, 0xb2, 0x75, 0xac, 0x59, 0xa7,
+	0xb6, 0x7d, 0x51, 0x73, 0x5c, 0x9b, 0xd8, 0x28, 0x1f, 0x12, 0xaa, 0x9f, 0x8d, 0x0c, 0x72, 0x7e,
+	0x79, 0x5a, 0xd3, 0xed, 0xf1, 0xde, 0xc8, 0x36, 0x35, 0x6b, 0xb4, 0xc7, 0x30, 0xa7, 0x97, 0x67,
+	0x7b, 0x0e, 0xb9, 0x71, 0xb0, 0xb7, 0x47, 0x8c, 0x31, 0xf6, 0x88, 0x36, 0x76, 0x26, 0xbf, 0xb8,
+	0x1c, 0xe9, 0x8f, 0x00, 0xe9, 0xae, 0x3b, 0xc4, 0x2e, 0x2a, 0x42, 0xc2, 0x18, 0x56, 0x84, 0x1d,
+	0x61, 0x37, 0xa5, 0x24, 0x8c, 0x21, 0xda, 0x80, 0xb4, 0xe3, 0x1a, 0x3a, 0xae, 0x24, 0x76, 0x84,
+	0xdd, 0xbc, 0xc2, 0x17, 0xa8, 0x0a, 0xb9, 0x6f, 0x2e, 0x35, 0x8b, 0x18, 0xe4, 0xa6, 0x92, 0x64,
+	0x8c, 0x70, 0x8d, 0x3e, 0x84, 0x94, 0x67, 0x0c, 0x71, 0x25, 0xb5, 0x23, 0xec, 0x16, 0xf7, 0x37,
+	0x6b, 0x13, 0x9d, 0xd9, 0x0e, 0xb5, 0xbe, 0x31, 0xc4, 0x0a, 0x83, 0xa0, 0x2d, 0xc8, 0x78, 0x37,
+	0xe3, 0x53, 0xdb, 0xac, 0xa4, 0x99, 0x10, 0x7f, 0x85, 0x3e, 0x86, 0xb4, 0x47, 0x34, 0x82, 0x2b,
+	0x19, 0x26, 0x63, 0x6b, 0x56, 0x06, 0xe5, 0x2a, 0x1c, 0x84, 0x1e, 0x02, 0x78, 0xc4, 0x76, 0x54,
+	0xae, 0x67, 0x96, 0x49, 0xca, 0x53, 0x4a, 0x8f, 0xe9, 0x5a, 0x83, 0x75, 0x63, 0x3c, 0xc6, 0x43,
+	0x43, 0x23, 0x58, 0xb5, 0x5d, 0x55, 0xd7, 0x2c, 0x1d, 0x9b, 0x95, 0xdc, 0x8e, 0xb0, 0x9b, 0x53,
+	0xca, 0x21, 0xab, 0xeb, 0x36, 0x18, 0x03, 0xed, 0xc0, 0xea, 0x99, 0x61, 0x9a, 0x14, 0x7a, 0x61,
+	0x98, 0x66, 0x25, 0xcf, 0x80, 0x40, 0x69, 0x5d, 0xf7, 0x95, 0x61, 0x9a, 0xe8, 0x3e, 0xe4, 0x1d,
+	0xdb, 0x23, 0xaa, 0x6d, 0x99, 0x37, 0x15, 0x60, 0xec, 0x1c, 0x25, 0x74, 0x2d, 0xf3, 0x06, 0xbd,
+	0x0f, 0xa5, 0x90, 0xa9, 0x7a, 0x26, 0xb5, 0x44, 0x81, 0x41, 0xd6, 0x02, 0x48, 0x9f, 0x12, 0xd1,
+	0x87, 0x20, 0x0e, 0x0d, 0xcf, 0x31, 0xb5, 0x1b, 0x35, 0x34, 0xe5, 0x2a, 0xd3, 0xbd, 0xe4, 0xd3,
+	0x5f, 0x07, 0x16, 0xdd, 0x86, 0x9c, 0x7d, 0x65, 0x61, 0x57, 0x35, 0x86, 0x95, 0x35, 0xe6, 0x99,
+	0x2c, 0x5b, 0xb7, 0x86, 0xe8, 0x2b, 0xd8, 0xf4, 0xb0, 0x79, 0xa6, 0x12, 0x57, 0x1b, 0x62, 0xd5,
+	0x71, 0xf1, 0x5b, 0x6c, 0x11, 0xc3, 0xb6, 0x2a, 0x45, 0x66, 0xb9, 0xc7, 0xb3, 0x96, 0xc3, 0xe6,
+	0xd9, 0x80, 0x82, 0x7b, 0x21, 0x56, 0x59, 0xf7, 0x66, 0x89, 0xe8, 0x53, 0x00, 0x7c, 0xed, 0x18,
+	0x2e, 0xf6, 0x54, 0x8d, 0x54, 0x4a, 0x3b, 0xc2, 0x6e, 0x61, 0xbf, 0x5a, 0x1b, 0xd9, 0xf6, 0xc8,
+	0xc4, 0xb5, 0x20, 0xb2, 0x6a, 0x83, 0x20, 0x90, 0x94, 0xbc, 0x8f, 0xae, 0x13, 0xb4, 0x0f, 0x59,
+	0xe2, 0x1a, 0xa3, 0x11, 0x76, 0x2b, 0x22, 0x53, 0xa3, 0x32, 0xa3, 0xc6, 0x80, 0xf3, 0x95, 0x00,
+	0x88, 0x9e, 0x03, 0x73, 0x99, 0x4a, 0x23, 0xb5, 0x52, 0x66, 0x5f, 0x6d, 0xcf, 0x71, 0xbb, 0xed,
+	0x0c, 0x6e, 0x1c, 0xac, 0xe4, 0x3c, 0xff, 0x17, 0xfa, 0x00, 0x4a, 0xc4, 0xd5, 0x0c, 0xd3, 0xb0,
+	0x46, 0xaa, 0x36, 0xb6, 0x2f, 0x2d, 0x52, 0x41, 0xcc, 0x8a, 0xc5, 0x80, 0x5c, 0x67, 0x54, 0x6a,
+	0xef, 0x10, 0xe8, 0x60, 0x57, 0xc7, 0x16, 0xa9, 0xac, 0x73, 0x7b, 0x07, 0xf4, 0x1e, 0x27, 0x53,
+	0x7b, 0x8f, 0x5c, 0xfb, 0xd2, 0xa1, 0xf6, 0xde, 0xe0, 0xf6, 0x66, 0xeb, 0xd6, 0x10, 0xbd, 0x07,
+	0xc5, 0x6f, 0x2e, 0x6d, 0x82, 0x27, 0x3e, 0xdb, 0x64, 0x32, 0xd6, 0x18, 0x35, 0xf4, 0xd8, 0x07,
+	0x50, 0xa2, 0xf1, 0x82, 0x87, 0x13, 0xdc, 0x16, 0xd7, 0x8a, 0x93, 0x43, 0xe0, 0x53, 0x40, 0x2e,
+	0x1e, 0x6b, 0x86, 0x45, 0xd5, 0x0a, 0xb1, 0xf7, 0x18, 0xb6, 0x1c, 0x72, 0x02, 0xb8, 0x54, 0x81,
+	0x14, 0x4d, 0x1f, 0x94, 0x85, 0x64, 0xbd, 0xff, 0x4a, 0x5c, 0xa1, 0x3f, 0x0e, 0x5a, 0x4d, 0x51,
+	0x90, 0x6c, 0x48, 0xb3, 0xa4, 0x40, 0x05, 0xc8, 0xf6, 0xe4, 0x4e, 0xb3, 0xd5, 0x39, 0x14, 0x57,
+	0x10, 0x40, 0xe6, 0x65, 0xab, 0xdd, 0x96, 0x9b, 0xa2, 0x80, 0xd6, 0x20, 0xdf, 0xa8, 0x77, 0x1a,
+	0x32, 0x5b, 0x26, 0xd0, 0x06, 0x88, 0xbd, 0xba, 0x32, 0x68, 0xd5, 0xdb, 0xed, 0x37, 0xaa, 0x0f,
+	0x4a, 0xa2, 0x55, 0xc8, 0x29, 0xf2, 0x17, 0x72, 0x63, 0x20, 0x37, 0xc5, 0x14, 0xfd, 0x64, 0xa0,
+	0xb4, 0x0e, 0x0f, 0x65, 0x45, 0x6e, 0x8a, 0x69, 0x2a, 0x5a, 0xfe, 0xaa, 0xd7, 0xa2, 0x8b, 0x8c,
+	0x74, 0x06, 0xeb, 0x73, 0x62, 0x09, 0x95, 0x61, 0x8d, 0xef, 0xa2, 0x76, 0xe4, 0x2f, 0xe5, 0xfe,
+	0x40, 0x5c, 0x89, 0x90, 0xba, 0xed, 0x26, 0x25, 0x09, 0xa8, 0x04, 0x05, 0x9f, 0x74, 0xd0, 0x1d,
+	0x1c, 0x89, 0x09, 0x54, 0x81, 0x8d, 0xa6, 0xdc, 0x50, 0xe4, 0x63, 0xb9, 0x33, 0x50, 0xeb, 0x9d,
+	0xa6, 0xca, 0xd9, 0x62, 0x52, 0x7a, 0x01, 0x59, 0x3f, 0x58, 0xd0, 0x3a, 0x94, 0x9a, 0xf2, 0xcb,
+	0xfa, 0x49, 0x7b, 0xa0, 0xfa, 0x6a, 0x89, 0x2b, 0x4c, 0xe3, 0x56, 0x5f, 0xee, 0xab, 0x83, 0xae,
+	0x28, 0xd0, 0xd5, 0xcb, 0x7a, 0xbb, 0xcd, 0x56, 0x09, 0xe9, 0x00, 0x72, 0x41, 0xc8, 0xa0, 0x4d,
+	0x28, 0x07, 0x1f, 0xf7, 0x07, 0xdd, 0x9e, 0x3a, 0x78, 0xd3, 0x93, 0xc5, 0x15, 0x54, 0x04, 0x60,
+	0xcb, 0x76, 0xeb, 0xb8, 0xe5, 0x6b, 0xc6, 0xd6, 0xc7, 0x75, 0xe5, 0x95, 0x3c, 0x10, 0x13, 0xd2,
+	0x9f, 0x12, 0x90, 0x66, 0x87, 0x9c, 0x29, 0x8d, 0x93, 0xea, 0x95, 0x88, 0x55, 0xaf, 0xb0, 0x64,
+	0x26, 0x17, 0x95, 0xcc, 0xd4, 0x54, 0xc9, 0xdc, 0x86, 0x1c, 0xd1, 0x2e, 0x78, 0x82, 0xa7, 0x79,
+	0xc0, 0xb1, 0x75, 0x6b, 0x48, 0x59, 0xe3, 0x80, 0x95, 0xe1, 0xac, 0xb1, 0xcf, 0xfa, 0x14, 0x40,
+	0x77, 0xb1, 0x46, 0xf0, 0x90, 0x66, 0x68, 0x76, 0x79, 0x86, 0xfa, 0xe8, 0x3a, 0x41, 0x8f, 0xa1,
+	0xc8, 0x37, 0x0c, 0xeb, 0x4a, 0x8e, 0xc9, 0x5e, 0x65, 0xd4, 0xae, 0x5f, 0x5c, 0x1e, 0x43, 0x71,
+	0x1c, 0x47, 0xe5, 0x39, 0x6a, 0x1c, 0x41, 0x49, 0x37, 0x50, 0x92, 0xaf, 0xb1, 0x7e, 0xc9, 0x4a,
+	0x09, 0x76, 0x6c, 0x97, 0xa0, 0xf7, 0x21, 0xcd, 0x0a, 0x12, 0x33, 0x56, 0x61, 0x5f, 0x8c, 0x24,
+	0x32, 0x33, 0xa5, 0xc2, 0xd9, 0x14, 0x67, 0xd3, 0xc4, 0xae, 0x24, 0x66, 0x70, 0x2c, 0xe1, 0x15,
+	0xce, 0xa6, 0x96, 0x76, 0xb1, 0xe6, 0xd9, 0x96, 0x6f, 0x52, 0x7f, 0x25, 0x7d, 0x97, 0x01, 0xd4,
+	0xb2, 0x3c, 0xec, 0x12, 0x0e, 0xc7, 0xdf, 0x5c, 0x62, 0x8f, 0xfc, 0x7f, 0xf4, 0xb0, 0x78, 0x57,
+	0xca, 0xdc, 0xb1, 0x2b, 0x65, 0xef, 0xda, 0x95, 0x72, 0xb7, 0x77, 0xa5, 0xfc, 0xf2, 0xae, 0x04,
+	0x77, 0xed, 0x4a, 0x85, 0xe5, 0x5d, 0x69, 0xf5, 0x8e, 0x5d, 0x69, 0xed, 0xa7, 0xed, 0x4a, 0xc5,
+	0x1f, 0xd8, 0x95, 0x4a, 0x3f, 0xa8, 0x2b, 0x89, 0x3f, 0xaa, 0x2b, 0x95, 0xef, 0xdc, 0x95, 0xd0,
+	0xfc, 0xae, 0x34, 0xdb, 0x7a, 0xd6, 0xe7, 0xb4, 0x1e, 0xe9, 0xef, 0x02, 0x6c, 0xfa, 0x39, 0xd1,
+	0xe8, 0xc6, 0xd2, 0x62, 0x12, 0xa9, 0x42, 0x2c, 0x52, 0xa3, 0xed, 0x2e, 0x11, 0x6f, 0x77, 0x9f,
+	0x40, 0xfa, 0xcc, 0x70, 0x3d, 0xc2, 0x12, 0xa4, 0xb0, 0xff, 0x30, 0x72, 0xf6, 0xd9, 0xbc, 0x53,
+	0x38, 0x16, 0xfd, 0x02, 0x32, 0x1e, 0xd6, 0x6d, 0x6b, 0x58, 0x49, 0xdd, 0xe5, 0x2b, 0x1f, 0x2c,
+	0x5d, 0x43, 0xb9, 0x3e, 0xc6, 0xd6, 0x30, 0xa6, 0x33, 0x0d, 0x32, 0xba, 0x56, 0xc3, 0x84, 0xce,
+	0xb2, 0x75, 0xeb, 0x27, 0x2c, 0xbf, 0xd2, 0x21, 0x20, 0x9e, 0x65, 0x3f, 0x72, 0x6b, 0xe9, 0x97,
+	0xb0, 0x1e, 0x13, 0xe4, 0x39, 0xb6, 0xe5, 0x45, 0xca, 0x9c, 0x70, 0x6b, 0x99, 0x93, 0xfe, 0x2a,
+	0x40, 0xf9, 0x58, 0xf3, 0x3c, 0x2e, 0x63, 0x99, 0xdb, 0x9e, 0x40, 0x9a, 0x16, 0x20, 0xaf, 0x92,
+	0xd8, 0x49, 0x2e, 0x2e, 0x52, 0x1c, 0x13, 0x4b, 0xd6, 0x64, 0x3c, 0x59, 0xef, 0x43, 0x7e, 0x6c,
+	0x58, 0x7e, 0x9d, 0xf2, 0x4d, 0x33, 0x36, 0x2c, 0x5e, 0xa6, 0x28, 0x53, 0xbb, 0xf6, 0x99, 0x69,
+	0x9f, 0xa9, 0x5d, 0x33, 0xa6, 0xf4, 0x33, 0x40, 0x51, 0x75, 0xfd, 0xd3, 0xde, 0x87, 0x7c, 0x60,
+	0x37, 0xaf, 0x22, 0xec, 0x24, 0x77, 0x53, 0x4a, 0xce, 0x37, 0x9c, 0x27, 0xfd, 0x53, 0x00, 0xf1,
+	0x58, 0x73, 0x2f, 0x30, 0xe9, 0xb9, 0x36, 0xc1, 0x3a, 0x4b, 0xea, 0x07, 0x90, 0x1f, 0xe2, 0xb7,
+	0x86, 0x46, 0x17, 0xfe, 0x21, 0x27, 0x04, 0xd4, 0x80, 0xbc, 0x8b, 0xcf, 0xb0, 0x8b, 0x2d, 0xbf,
+	0x82, 0x17, 0xf7, 0xdf, 0x8b, 0x9c, 0x75, 0x5a, 0x5a, 0x4d, 0x09, 0xc0, 0xca, 0xe4, 0x3b, 0x9a,
+	0x3c, 0x2e, 0xf6, 0x88, 0xca, 0x47, 0x2a, 0xea, 0x8b, 0x24, 0x2f, 0x7f, 0x94, 0xaa, 0x04, 0x44,
+	0xe9, 0x09, 0xe4, 0xc3, 0xcf, 0xe9, 0x68, 0xd0, 0xae, 0xf7, 0x07, 0x6a, 0x4f, 0x69, 0x35, 0xfc,
+	0x51, 0xe1, 0x40, 0x0e, 0xd7, 0x82, 0xf4, 0xbb, 0x04, 0x00, 0xdf, 0xbd, 0xef, 0x60, 0x9d, 0x9e,
+	0x9b, 0x18, 0xfa, 0x85, 0xea, 0x19, 0xdf, 0x62, 0xff, 0x14, 0x39, 0x4a, 0xe8, 0x1b, 0xdf, 0x62,
+	0x6a, 0x7f, 0xd3, 0x26, 0x9c, 0xc7, 0x63, 0x26, 0x6b, 0xda, 0x84, 0xb1, 0x3e, 0x80, 0x12, 0x33,
+	0x2f, 0xad, 0x93, 0xba, 0xe1, 0x19, 0x7e, 0x97, 0x5b, 0x53, 0x8a, 0x8c, 0xdc, 0x0b, 0xa8, 0x74,
+	0x56, 0x0c, 0x42, 0x36, 0x82, 0x4d, 0x31, 0x6c, 0x39, 0xe0, 0x4c, 0xe0, 0x8f, 0x60, 0x95, 0xfa,
+	0x35, 0x8c, 0x7a, 0xee, 0xbd, 0xc2, 0xd8, 0xb0, 0xc2, 0x12, 0x4e, 0x21, 0xda, 0xf5, 0x04, 0x92,
+	0xf1, 0x21, 0xda, 0x75, 0x0c, 0x62, 0x58, 0xaa, 0x65, 0x53, 0xdb, 0x6a, 0x66, 0x25, 0x1b, 0x4a,
+	0xe9, 0xf8, 0x24, 0xe9, 0x2f, 0x02, 0x64, 0x8f, 0x35, 0xa2, 0x9f, 0x63, 0x17, 0xbd, 0x80, 0xbc,
+	0x66, 0x8e, 0x6c, 0xd7, 0x20, 0xe7, 0x63, 0x66, 0x84, 0xe2, 0xfe, 0x83, 0x98, 0xb3, 0x18, 0xac,
+	0x56, 0x0f, 0x30, 0xca, 0x04, 0x8e, 0x24, 0x58, 0xa3, 0xb5, 0x96, 0x07, 0x8f, 0xae, 0x39, 0xbe,
+	0xa1, 0x0a, 0xc4, 0x76, 0x58, 0x40, 0x37, 0x34, 0x47, 0xfa, 0x0c, 0xf2, 0xe1, 0xb7, 0x28, 0x07,
+	0xa9, 0x97, 0xad, 0x97, 0x5d, 0x3e, 0x04, 0xf6, 0x94, 0xae, 0xaa, 0xd4, 0x07, 0x75, 0x51, 0x40,
+	0x5b, 0x80, 0x82, 0x95, 0x4a, 0x67, 0xb9, 0xae, 0xd2, 0x94, 0x15, 0x31, 0x21, 0x5d, 0x41, 0xb1,
+	0x61, 0xb8, 0xfa, 0xa5, 0x41, 0x0e, 0x5c, 0x4c, 0x47, 0x18, 0x84, 0x20, 0x75, 0xaa, 0x59, 0x43,
+	0xdf, 0x5d, 0xec, 0x37, 0x0d, 0x95, 0x2b, 0xc3, 0x1a, 0xda, 0x57, 0x2a, 0x2f, 0x4c, 0x1e, 0xd3,
+	0x23, 0xa9, 0xac, 0x71, 0x6a, 0x9f, 0x13, 0xd1, 0x47, 0x50, 0x76, 0xb1, 0xed, 0x60, 0x4b, 0x3d,
+	0xbd, 0x51, 0xb5, 0x4b, 0x9d, 0x04, 0x8e, 0xcb, 0x29, 0x25, 0xce, 0x38, 0xb8, 0xa9, 0x73, 0xb2,
+	0xf4, 0x9f, 0x04, 0xac, 0x77, 0xf0, 0x15, 0x3b, 0xc5, 0x81, 0x6d, 0x5f, 0x2c, 0x4b, 0xed, 0x23,
+	0x28, 0x8f, 0x59, 0x60, 0xa9, 0x4e, 0x18, 0xd7, 0xfe, 0x8c, 0x74, 0xff, 0x96, 0xd0, 0x57, 0xc4,
+	0xf1, 0x14, 0x05, 0x3d, 0x87, 0x82, 0x2f, 0xc9, 0x73, 0xb0, 0xee, 0x97, 0xf1, 0xcd, 0x19, 0x19,
+	0x34, 0x80, 0x15, 0x18, 0x87, 0xbf, 0xd1, 0xc7, 0x90, 0x1d, 0x73, 0x5f, 0xf9, 0x45, 0x1c, 0xcd,
+	0x7a, 0x51, 0x09, 0x20, 0xe8, 0x73, 0x58, 0xa3, 0xad, 0x9e, 0x36, 0x31, 0x7e, 0x6f, 0x4f, 0x33,
+	0xcf, 0xdf, 0x9b, 0x9a, 0xfb, 0x0c, 0x6b, 0xc4, 0x2f, 0xee, 0xab, 0x24, 0xb2, 0x42, 0x07, 0x50,
+	0xd2, 0xb9, 0x5b, 0xd4, 0x53, 0xee, 0x17, 0x16, 0x88, 0x85, 0x58, 0xab, 0x8d, 0x3b, 0x4e, 0x29,
+	0xea, 0xb1, 0xb5, 0xb4, 0x05, 0x1b, 0x71, 0x03, 0xf3, 0x62, 0x24, 0xa9, 0xb0, 0xd5, 0xc7, 0x24,
+	0xb6, 0xf9, 0x12, 0xdb, 0x3f, 0x0d, 0xde, 0x1e, 0x12, 0xb7, 0x9f, 0x81, 0xa3, 0xa4, 0x6d, 0xb8,
+	0x37, 0xb3, 0x81, 0xbf, 0xf7, 0x33, 0xd8, 0x3a, 0xfc, 0x5e, 0x7b, 0x4b, 0x47, 0x70, 0xef, 0x70,
+	0xbe, 0xb0, 0x89, 0x5a, 0xc2, 0x9d, 0xd4, 0x7a, 0x0a, 0xeb, 0x7d, 0xa2, 0xb9, 0xc4, 0x8f, 0xc0,
+	0x65, 0x1b, 0x6f, 0xc1, 0x46, 0x1c, 0xee, 0x1f, 0x61, 0x17, 0x8a, 0x27, 0x96, 0xee, 0xda, 0x9e,
+	0xb7, 0x4c, 0x42, 0x13, 0x4a, 0x87, 0x98, 0xdc, 0x75, 0xde, 0x08, 0x1b, 0x6b, 0x22, 0xd6, 0x58,
+	0xa5, 0xbf, 0x09, 0xb0, 0xd9, 0x36, 0x3c, 0xd2, 0x75, 0xb0, 0xc5, 0x64, 0x79, 0xff, 0xab, 0x2e,
+	0xf8, 0x10, 0xc0, 0xd1, 0x46, 0x58, 0x25, 0xf6, 0x05, 0xe6, 0x45, 0x35, 0xa5, 0xe4, 0x29, 0x65,
+	0x40, 0x09, 0x6c, 0xb8, 0xa6, 0x6c, 0x56, 0xc0, 0xd3, 0xac, 0xe4, 0xe6, 0x28, 0x81, 0x56, 0x70,
+	0xe9, 0xb7, 0xb0, 0x35, 0xad, 0xb4, 0xef, 0xb5, 0x5d, 0xc8, 0xb0, 0xa3, 0xf1, 0x46, 0x38, 0xaf,
+	0xf5, 0xfb, 0x7c, 0x3a, 0xa0, 0x5b, 0xf8, 0x9a, 0xa8, 0x11, 0x25, 0xb8, 0x6d, 0xd6, 0x28, 0xb9,
+	0x17, 0x28, 0x22, 0xfd, 0x86, 0xd9, 0xb9, 0x89, 0x1d, 0x72, 0xbe, 0xcc, 0x34, 0x0f, 0x20, 0x6f,
+	0x58, 0xba, 0x8b, 0xc7, 0x74, 0xa8, 0xe4, 0xb5, 0x74, 0x42, 0xa0, 0x63, 0x92, 0x69, 0x8c, 0x0d,
+	0xe2, 0x37, 0x1b, 0xbe, 0x90, 0x34, 0x00, 0xd6, 0xdb, 0xdb, 0xf8, 0x2d, 0x8e, 0x8c, 0x52, 0xc2,
+	0xa2, 0x51, 0x2a, 0x31, 0x75, 0x71, 0x7a, 0x04, 0xab, 0xfc, 0x40, 0xaa, 0xce, 0xa6, 0x5e, 0x6e,
+	0xe5, 0x02, 0xa7, 0x35, 0x28, 0x49, 0xfa, 0x4e, 0x80, 0x34, 0xd3, 0x7f, 0xa1, 0xe2, 0x1f, 0x42,
+	0xea, 0xd4, 0x18, 0x72, 0x97, 0xc6, 0xab, 0xd5, 0x44, 0x37, 0x85, 0x41, 0x28, 0x54, 0xf3, 0x2e,
+	0xbc, 0x4a, 0xf2, 0x56, 0x28, 0x85, 0x50, 0xb5, 0x3d, 0x6a, 0x31, 0xcb, 0x1f, 0x73, 0x52, 0x4a,
+	0xb8, 0x96, 0xf6, 0x60, 0xb3, 0x7f, 0x79, 0xea, 0xe9, 0xae, 0x71, 0x8a, 0xef, 0x62, 0x5b, 0xe9,
+	0x0f, 0x02, 0x14, 0x18, 0xf0, 0xc4, 0x19, 0xd2, 0x1a, 0x16, 0x15, 0x2e, 0xc4, 0x85, 0x87, 0x97,
+	0xc9, 0xc4, 0xf2, 0xcb, 0xe4, 0x73, 0x28, 0xf0, 0x59, 0xc0, 0xa4, 0x8a, 0xcf, 0x29, 0xd7, 0x91,
+	0x53, 0x81, 0x13, 0xfe, 0x96, 0xae, 0x00, 0x98, 0x36, 0x32, 0xbd, 0x27, 0xa1, 0x1a, 0xe4, 0x3c,
+	0x4b, 0x73, 0xbc, 0x73, 0x9b, 0xcc, 0x19, 0x39, 0x19, 0xf0, 0x68, 0x45, 0x09, 0x31, 0xe8, 0x19,
+	0x64, 0x2e, 0xd9, 0x31, 0xfc, 0x1e, 0xb3, 0x35, 0x8d, 0xe6, 0x87, 0x3c, 0x5a, 0x51, 0x7c, 0xdc,
+	0x41, 0x16, 0xd2, 0xec, 0x4a, 0x26, 0xfd, 0x5b, 0x80, 0xad, 0xd0, 0x72, 0xec, 0xb2, 0xb6, 0x34,
+	0x63, 0x4d, 0xd8, 0xf2, 0x4c, 0xda, 0x5d, 0x83, 0xcf, 0x5c, 0xd5, 0xb1, 0x4d, 0x43, 0xbf, 0xf1,
+	0x0d, 0xf4, 0x3c, 0xb2, 0xfb, 0x7c, 0xd1, 0xb5, 0xbe, 0x69, 0x5f, 0x85, 0x2c, 0xb7, 0xc7, 0xbe,
+	0x56, 0x36, 0xbc, 0x39, 0x54, 0xe9, 0x19, 0x6c, 0xcc, 0x43, 0xd3, 0x61, 0xae, 0xd9, 0xea, 0x37,
+	0xba, 0x9d, 0x8e, 0xdc, 0xa0, 0x8f, 0x54, 0x39, 0x48, 0x35, 0x95, 0x6e, 0x4f, 0x14, 0xa4, 0x4f,
+	0x60, 0x3b, 0x44, 0xdf, 0xb5, 0x63, 0x4b, 0x27, 0xb0, 0x13, 0x7e, 0x34, 0xf5, 0x1a, 0xe2, 0xdd,
+	0xa5, 0x1e, 0x06, 0x55, 0x29, 0x11, 0xab, 0x4a, 0xd2, 0x15, 0xac, 0x2a, 0xd8, 0x23, 0x86, 0x35,
+	0x62, 0x9a, 0xdc, 0x76, 0x27, 0xf9, 0xfe, 0x8f, 0x1c, 0x55, 0xa0, 0xef, 0x03, 0x06, 0x99, 0x4c,
+	0x91, 0xe1, 0x5a, 0xfa, 0xb3, 0x00, 0xe5, 0xf0, 0xf0, 0xfd, 0x20, 0x50, 0x16, 0x9d, 0x20, 0x1a,
+	0xfd, 0x89, 0xa9, 0xe8, 0x7f, 0xe2, 0x27, 0x33, 0xcf, 0xd0, 0x68, 0xdf, 0x8a, 0x9e, 0xcc, 0x4f,
+	0xe7, 0x27, 0x7e, 0x3a, 0xa7, 0x96, 0x80, 0x29, 0x48, 0xfa, 0x57, 0x02, 0x80, 0xad, 0x79, 0xd4,
+	0xdf, 0x96, 0x82, 0x35, 0x48, 0xb1, 0x2b, 0x3c, 0x8f, 0xb0, 0xea, 0x74, 0x0a, 0x32, 0x01, 0x35,
+	0x76, 0x87, 0x67, 0xb8, 0x98, 0x9d, 0x93, 0x71, 0x3b, 0x7f, 0x8f, 0xa7, 0xa1, 0xd0, 0x25, 0xe9,
+	0x45, 0x2e, 0xc9, 0x4c, 0xb9, 0xe4, 0x09, 0x94, 0x31, 0x8b, 0x9e, 0xe8, 0xcb, 0x31, 0x1f, 0xb9,
+	0xc5, 0x80, 0xf1, 0x7a, 0x9e, 0xff, 0x72, 0x53, 0xfe, 0xfb, 0x39, 0xa4, 0xe8, 0x71, 0xd8, 0x43,
+	0x71, 0xb3, 0xc9, 0x5f, 0x82, 0x8f, 0xbb, 0xcd, 0xd6, 0xcb, 0x37, 0xa2, 0x40, 0x7f, 0x37, 0xe5,
+	0xb6, 0x3c, 0x90, 0xc5, 0x04, 0x7f, 0xd3, 0x95, 0x1b, 0x27, 0x03, 0x59, 0x4c, 0x4a, 0xbf, 0x17,
+	0xa0, 0x18, 0x7a, 0x9d, 0x5b, 0xf5, 0xc5, 0x4c, 0x2d, 0x79, 0x30, 0x7d, 0xe4, 0x68, 0x88, 0xc4,
+	0xea, 0xca, 0xde, 0x54, 0x5d, 0xd9, 0x9c, 0x6b, 0xf7, 0x39, 0x65, 0xe5, 0xa3, 0x21, 0xac, 0x46,
+	0xa7, 0x1a, 0x9a, 0xad, 0x8d, 0x6e, 0x67, 0xd0, 0xea, 0x9c, 0x74, 0x4f, 0xfa, 0xc1, 0xbc, 0x2f,
+	0xab, 0xdd, 0x9e, 0xdc, 0xe1, 0x2f, 0xdb, 0xbd, 0x6e, 0x7f, 0xa0, 0x76, 0x3b, 0xed, 0x37, 0x62,
+	0x22, 0xf2, 0xb8, 0xcc, 0x08, 0x49, 0x7a, 0xde, 0xa3, 0x7a, 0x9b, 0x3f, 0x69, 0x03, 0x64, 0x1a,
+	0xed, 0x6e, 0x9f, 0xbe, 0x67, 0xef, 0xff, 0x03, 0x20, 0xdf, 0x0d, 0x34, 0x42, 0xaf, 0x61, 0x35,
+	0x3a, 0x42, 0xa2, 0x77, 0x22, 0xda, 0xce, 0x19, 0xde, 0xab, 0xef, 0x2e, 0xe4, 0xfb, 0xc3, 0xd3,
+	0x0a, 0x3a, 0x80, 0x42, 0xe4, 0xc1, 0x03, 0xdd, 0xfe, 0x10, 0x52, 0x9d, 0x79, 0x26, 0x95, 0x56,
+	0x9e, 0x09, 0xe8, 0x08, 0x8a, 0xf1, 0xe7, 0x1c, 0xb4, 0x33, 0x2b, 0xa6, 0xd1, 0xbd, 0x83, 0xa4,
+	0x5f, 0x03, 0x4c, 0x1e, 0x58, 0x50, 0xd4, 0x8d, 0x33, 0xef, 0x2e, 0x0b, 0x24, 0x74, 0xa0, 0x10,
+	0x79, 0xdf, 0x88, 0x9d, 0x67, 0xf6, 0x01, 0xa5, 0xfa, 0xce, 0x22, 0x76, 0x68, 0x9f, 0x57, 0x00,
+	0x93, 0x07, 0x04, 0x14, 0xbf, 0x28, 0x4e, 0x3d, 0x83, 0x54, 0x1f, 0x2e, 0xe0, 0x86, 0xc2, 0x5e,
+	0x40, 0x2e, 0x98, 0x40, 0x51, 0x34, 0xc3, 0xa7, 0xc6, 0xd2, 0xea, 0xcc, 0x0c, 0x26, 0xad, 0xa0,
+	0x2f, 0xa1, 0x18, 0x9f, 0xe0, 0x62, 0x46, 0x9e, 0x3b, 0x91, 0x56, 0x1f, 0xdd, 0x82, 0x98, 0x52,
+	0x8a, 0x8f, 0x3b, 0x53, 0x4a, 0x45, 0xe7, 0x8c, 0xea, 0x4c, 0x83, 0x96, 0x56, 0xd0, 0x31, 0x14,
+	0xe3, 0x43, 0x49, 0x4c, 0xa9, 0xb9, 0xf3, 0x4a, 0x75, 0x73, 0x5a, 0x0e, 0x4b, 0x31, 0xe6, 0xbc,
+	0x2f, 0xa0, 0x34, 0xd5, 0x4e, 0xd1, 0xa3, 0xa5, 0xad, 0x76, 0x41, 0x20, 0xbc, 0x01, 0x34, 0xdb,
+	0x23, 0xd1, 0xe3, 0x79, 0xe2, 0x66, 0xf2, 0x66, 0x7b, 0x5e, 0xfd, 0x98, 0xa8, 0x79, 0x0e, 0xdb,
+	0x0b, 0x3b, 0x29, 0x7a, 0x32, 0x6f, 0x87, 0x05, 0xfd, 0xb6, 0x1a, 0xb5, 0xf7, 0x14, 0x86, 0xed,
+	0xf4, 0x35, 0x94, 0xa6, 0xae, 0x6e, 0x71, 0x83, 0xcc, 0xbd, 0xbb, 0x55, 0xa5, 0xdb, 0x20, 0xa1,
+	0xdf, 0xbf, 0x66, 0x63, 0xfa, 0x42, 0xd9, 0x87, 0xcb, 0x65, 0x1f, 0x2e, 0x94, 0xfd, 0x1a, 0x56,
+	0xa3, 0x97, 0xb5, 0x58, 0xa1, 0x9a, 0x73, 0xe9, 0xab, 0xbe, 0xbb, 0x90, 0x1f, 0x09, 0xd3, 0xac,
+	0x7f, 0xcf, 0x43, 0x51, 0xf7, 0xc4, 0xef, 0x7e, 0xf3, 0x63, 0xe1, 0xe0, 0x57, 0x5f, 0x7f, 0x1e,
+	0xf9, 0xeb, 0xc1, 0xd0, 0xd5, 0xde, 0x62, 0x0b, 0x7b, 0xde, 0x5e, 0x88, 0xdd, 0xd3, 0x1c, 0x23,
+	0xfc, 0x2f, 0xc2, 0x53, 0xfa, 0x0e, 0x31, 0xe1, 0x39, 0xa7, 0xa7, 0x19, 0xc6, 0xfa, 0xe4, 0xbf,
+	0x03, 0x00, 0xe5, 0x67, 0x38, 0x15, 0xdd, 0x20, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -720,33 +3010,342 @@ const _ = grpc.SupportPackageIsVersion4
 type OceanbookClient interface {
 	NewOrderBook(ctx context.Context, in *NewOrderBookRequest, opts ...grpc.CallOption) (*NewOrderBookResponse, error)
 	InsertOrder(ctx context.Context, in *InsertOrderRequest, opts ...grpc.CallOption) (Oceanbook_InsertOrderClient, error)
+	InsertOCOOrder(ctx context.Context, in *InsertOCOOrderRequest, opts ...grpc.CallOption) (Oceanbook_InsertOCOOrderClient, error)
+	AmendOrder(ctx context.Context, in *AmendOrderRequest, opts ...grpc.CallOption) (Oceanbook_AmendOrderClient, error)
 	CancelOrder(ctx context.Context, in *CancelOrderRequest, opts ...grpc.CallOption) (*CancelOrderResponse, error)
+	MassCancel(ctx context.Context, in *MassCancelRequest, opts ...grpc.CallOption) (*MassCancelResponse, error)
+	GetOrder(ctx context.Context, in *GetOrderRequest, opts ...grpc.CallOption) (*Order, error)
+	ListOpenOrders(ctx context.Context, in *ListOpenOrdersRequest, opts ...grpc.CallOption) (*ListOpenOrdersResponse, error)
 	GetDepth(ctx context.Context, in *GetDepthRequest, opts ...grpc.CallOption) (*Depth, error)
+	SubscribeDepth(ctx context.Context, in *SubscribeDepthRequest, opts ...grpc.CallOption) (Oceanbook_SubscribeDepthClient, error)
+	SubscribeTrades(ctx context.Context, in *SubscribeTradesRequest, opts ...grpc.CallOption) (Oceanbook_SubscribeTradesClient, error)
+	SubscribeOrderBook(ctx context.Context, in *SubscribeOrderBookRequest, opts ...grpc.CallOption) (Oceanbook_SubscribeOrderBookClient, error)
+	SubscribeExecutionReports(ctx context.Context, in *SubscribeExecutionReportsRequest, opts ...grpc.CallOption) (Oceanbook_SubscribeExecutionReportsClient, error)
+	SetTradingState(ctx context.Context, in *SetTradingStateRequest, opts ...grpc.CallOption) (*SetTradingStateResponse, error)
+	GetTradingState(ctx context.Context, in *GetTradingStateRequest, opts ...grpc.CallOption) (*GetTradingStateResponse, error)
+	StartAuction(ctx context.Context, in *StartAuctionRequest, opts ...grpc.CallOption) (*StartAuctionResponse, error)
+	Uncross(ctx context.Context, in *UncrossRequest, opts ...grpc.CallOption) (Oceanbook_UncrossClient, error)
+}
+
+type oceanbookClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewOceanbookClient(cc *grpc.ClientConn) OceanbookClient {
+	return &oceanbookClient{cc}
+}
+
+func (c *oceanbookClient) NewOrderBook(ctx context.Context, in *NewOrderBookRequest, opts ...grpc.CallOption) (*NewOrderBookResponse, error) {
+	out := new(NewOrderBookResponse)
+	err := c.cc.Invoke(ctx, "/oceanbook.Oceanbook/NewOrderBook", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *oceanbookClient) InsertOrder(ctx context.Context, in *InsertOrderRequest, opts ...grpc.CallOption) (Oceanbook_InsertOrderClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Oceanbook_serviceDesc.Streams[0], "/oceanbook.Oceanbook/InsertOrder", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &oceanbookInsertOrderClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Oceanbook_InsertOrderClient interface {
+	Recv() (*Trade, error)
+	grpc.ClientStream
+}
+
+type oceanbookInsertOrderClient struct {
+	grpc.ClientStream
+}
+
+func (x *oceanbookInsertOrderClient) Recv() (*Trade, error) {
+	m := new(Trade)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *oceanbookClient) InsertOCOOrder(ctx context.Context, in *InsertOCOOrderRequest, opts ...grpc.CallOption) (Oceanbook_InsertOCOOrderClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Oceanbook_serviceDesc.Streams[1], "/oceanbook.Oceanbook/InsertOCOOrder", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &oceanbookInsertOCOOrderClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Oceanbook_InsertOCOOrderClient interface {
+	Recv() (*Trade, error)
+	grpc.ClientStream
+}
+
+type oceanbookInsertOCOOrderClient struct {
+	grpc.ClientStream
+}
+
+func (x *oceanbookInsertOCOOrderClient) Recv() (*Trade, error) {
+	m := new(Trade)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *oceanbookClient) AmendOrder(ctx context.Context, in *AmendOrderRequest, opts ...grpc.CallOption) (Oceanbook_AmendOrderClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Oceanbook_serviceDesc.Streams[2], "/oceanbook.Oceanbook/AmendOrder", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &oceanbookAmendOrderClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Oceanbook_AmendOrderClient interface {
+	Recv() (*Trade, error)
+	grpc.ClientStream
+}
+
+type oceanbookAmendOrderClient struct {
+	grpc.ClientStream
+}
+
+func (x *oceanbookAmendOrderClient) Recv() (*Trade, error) {
+	m := new(Trade)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *oceanbookClient) CancelOrder(ctx context.Context, in *CancelOrderRequest, opts ...grpc.CallOption) (*CancelOrderResponse, error) {
+	out := new(CancelOrderResponse)
+	err := c.cc.Invoke(ctx, "/oceanbook.Oceanbook/CancelOrder", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *oceanbookClient) MassCancel(ctx context.Context, in *MassCancelRequest, opts ...grpc.CallOption) (*MassCancelResponse, error) {
+	out := new(MassCancelResponse)
+	err := c.cc.Invoke(ctx, "/oceanbook.Oceanbook/MassCancel", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *oceanbookClient) GetOrder(ctx context.Context, in *GetOrderRequest, opts ...grpc.CallOption) (*Order, error) {
+	out := new(Order)
+	err := c.cc.Invoke(ctx, "/oceanbook.Oceanbook/GetOrder", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *oceanbookClient) ListOpenOrders(ctx context.Context, in *ListOpenOrdersRequest, opts ...grpc.CallOption) (*ListOpenOrdersResponse, error) {
+	out := new(ListOpenOrdersResponse)
+	err := c.cc.Invoke(ctx, "/oceanbook.Oceanbook/ListOpenOrders", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *oceanbookClient) GetDepth(ctx context.Context, in *GetDepthRequest, opts ...grpc.CallOption) (*Depth, error) {
+	out := new(Depth)
+	err := c.cc.Invoke(ctx, "/oceanbook.Oceanbook/GetDepth", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *oceanbookClient) SubscribeDepth(ctx context.Context, in *SubscribeDepthRequest, opts ...grpc.CallOption) (Oceanbook_SubscribeDepthClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Oceanbook_serviceDesc.Streams[3], "/oceanbook.Oceanbook/SubscribeDepth", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &oceanbookSubscribeDepthClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Oceanbook_SubscribeDepthClient interface {
+	Recv() (*DepthEvent, error)
+	grpc.ClientStream
+}
+
+type oceanbookSubscribeDepthClient struct {
+	grpc.ClientStream
+}
+
+func (x *oceanbookSubscribeDepthClient) Recv() (*DepthEvent, error) {
+	m := new(DepthEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *oceanbookClient) SubscribeTrades(ctx context.Context, in *SubscribeTradesRequest, opts ...grpc.CallOption) (Oceanbook_SubscribeTradesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Oceanbook_serviceDesc.Streams[4], "/oceanbook.Oceanbook/SubscribeTrades", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &oceanbookSubscribeTradesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Oceanbook_SubscribeTradesClient interface {
+	Recv() (*Trade, error)
+	grpc.ClientStream
+}
+
+type oceanbookSubscribeTradesClient struct {
+	grpc.ClientStream
+}
+
+func (x *oceanbookSubscribeTradesClient) Recv() (*Trade, error) {
+	m := new(Trade)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *oceanbookClient) SubscribeOrderBook(ctx context.Context, in *SubscribeOrderBookRequest, opts ...grpc.CallOption) (Oceanbook_SubscribeOrderBookClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Oceanbook_serviceDesc.Streams[5], "/oceanbook.Oceanbook/SubscribeOrderBook", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &oceanbookSubscribeOrderBookClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Oceanbook_SubscribeOrderBookClient interface {
+	Recv() (*OrderBookEvent, error)
+	grpc.ClientStream
+}
+
+type oceanbookSubscribeOrderBookClient struct {
+	grpc.ClientStream
+}
+
+func (x *oceanbookSubscribeOrderBookClient) Recv() (*OrderBookEvent, error) {
+	m := new(OrderBookEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *oceanbookClient) SubscribeExecutionReports(ctx context.Context, in *SubscribeExecutionReportsRequest, opts ...grpc.CallOption) (Oceanbook_SubscribeExecutionReportsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Oceanbook_serviceDesc.Streams[6], "/oceanbook.Oceanbook/SubscribeExecutionReports", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &oceanbookSubscribeExecutionReportsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Oceanbook_SubscribeExecutionReportsClient interface {
+	Recv() (*ExecutionReport, error)
+	grpc.ClientStream
+}
+
+type oceanbookSubscribeExecutionReportsClient struct {
+	grpc.ClientStream
+}
+
+func (x *oceanbookSubscribeExecutionReportsClient) Recv() (*ExecutionReport, error) {
+	m := new(ExecutionReport)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
 }
 
-type oceanbookClient struct {
-	cc *grpc.ClientConn
+func (c *oceanbookClient) SetTradingState(ctx context.Context, in *SetTradingStateRequest, opts ...grpc.CallOption) (*SetTradingStateResponse, error) {
+	out := new(SetTradingStateResponse)
+	err := c.cc.Invoke(ctx, "/oceanbook.Oceanbook/SetTradingState", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func NewOceanbookClient(cc *grpc.ClientConn) OceanbookClient {
-	return &oceanbookClient{cc}
+func (c *oceanbookClient) GetTradingState(ctx context.Context, in *GetTradingStateRequest, opts ...grpc.CallOption) (*GetTradingStateResponse, error) {
+	out := new(GetTradingStateResponse)
+	err := c.cc.Invoke(ctx, "/oceanbook.Oceanbook/GetTradingState", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func (c *oceanbookClient) NewOrderBook(ctx context.Context, in *NewOrderBookRequest, opts ...grpc.CallOption) (*NewOrderBookResponse, error) {
-	out := new(NewOrderBookResponse)
-	err := c.cc.Invoke(ctx, "/oceanbook.Oceanbook/NewOrderBook", in, out, opts...)
+func (c *oceanbookClient) StartAuction(ctx context.Context, in *StartAuctionRequest, opts ...grpc.CallOption) (*StartAuctionResponse, error) {
+	out := new(StartAuctionResponse)
+	err := c.cc.Invoke(ctx, "/oceanbook.Oceanbook/StartAuction", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *oceanbookClient) InsertOrder(ctx context.Context, in *InsertOrderRequest, opts ...grpc.CallOption) (Oceanbook_InsertOrderClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_Oceanbook_serviceDesc.Streams[0], "/oceanbook.Oceanbook/InsertOrder", opts...)
+func (c *oceanbookClient) Uncross(ctx context.Context, in *UncrossRequest, opts ...grpc.CallOption) (Oceanbook_UncrossClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Oceanbook_serviceDesc.Streams[7], "/oceanbook.Oceanbook/Uncross", opts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &oceanbookInsertOrderClient{stream}
+	x := &oceanbookUncrossClient{stream}
 	if err := x.ClientStream.SendMsg(in); err != nil {
 		return nil, err
 	}
@@ -756,16 +3355,16 @@ func (c *oceanbookClient) InsertOrder(ctx context.Context, in *InsertOrderReques
 	return x, nil
 }
 
-type Oceanbook_InsertOrderClient interface {
+type Oceanbook_UncrossClient interface {
 	Recv() (*Trade, error)
 	grpc.ClientStream
 }
 
-type oceanbookInsertOrderClient struct {
+type oceanbookUncrossClient struct {
 	grpc.ClientStream
 }
 
-func (x *oceanbookInsertOrderClient) Recv() (*Trade, error) {
+func (x *oceanbookUncrossClient) Recv() (*Trade, error) {
 	m := new(Trade)
 	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
@@ -773,30 +3372,25 @@ func (x *oceanbookInsertOrderClient) Recv() (*Trade, error) {
 	return m, nil
 }
 
-func (c *oceanbookClient) CancelOrder(ctx context.Context, in *CancelOrderRequest, opts ...grpc.CallOption) (*CancelOrderResponse, error) {
-	out := new(CancelOrderResponse)
-	err := c.cc.Invoke(ctx, "/oceanbook.Oceanbook/CancelOrder", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
-}
-
-func (c *oceanbookClient) GetDepth(ctx context.Context, in *GetDepthRequest, opts ...grpc.CallOption) (*Depth, error) {
-	out := new(Depth)
-	err := c.cc.Invoke(ctx, "/oceanbook.Oceanbook/GetDepth", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
-}
-
 // OceanbookServer is the server API for Oceanbook service.
 type OceanbookServer interface {
 	NewOrderBook(context.Context, *NewOrderBookRequest) (*NewOrderBookResponse, error)
 	InsertOrder(*InsertOrderRequest, Oceanbook_InsertOrderServer) error
+	InsertOCOOrder(*InsertOCOOrderRequest, Oceanbook_InsertOCOOrderServer) error
+	AmendOrder(*AmendOrderRequest, Oceanbook_AmendOrderServer) error
 	CancelOrder(context.Context, *CancelOrderRequest) (*CancelOrderResponse, error)
+	MassCancel(context.Context, *MassCancelRequest) (*MassCancelResponse, error)
+	GetOrder(context.Context, *GetOrderRequest) (*Order, error)
+	ListOpenOrders(context.Context, *ListOpenOrdersRequest) (*ListOpenOrdersResponse, error)
 	GetDepth(context.Context, *GetDepthRequest) (*Depth, error)
+	SubscribeDepth(*SubscribeDepthRequest, Oceanbook_SubscribeDepthServer) error
+	SubscribeTrades(*SubscribeTradesRequest, Oceanbook_SubscribeTradesServer) error
+	SubscribeOrderBook(*SubscribeOrderBookRequest, Oceanbook_SubscribeOrderBookServer) error
+	SubscribeExecutionReports(*SubscribeExecutionReportsRequest, Oceanbook_SubscribeExecutionReportsServer) error
+	SetTradingState(context.Context, *SetTradingStateRequest) (*SetTradingStateResponse, error)
+	GetTradingState(context.Context, *GetTradingStateRequest) (*GetTradingStateResponse, error)
+	StartAuction(context.Context, *StartAuctionRequest) (*StartAuctionResponse, error)
+	Uncross(*UncrossRequest, Oceanbook_UncrossServer) error
 }
 
 func RegisterOceanbookServer(s *grpc.Server, srv OceanbookServer) {
@@ -842,6 +3436,48 @@ func (x *oceanbookInsertOrderServer) Send(m *Trade) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _Oceanbook_InsertOCOOrder_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(InsertOCOOrderRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(OceanbookServer).InsertOCOOrder(m, &oceanbookInsertOCOOrderServer{stream})
+}
+
+type Oceanbook_InsertOCOOrderServer interface {
+	Send(*Trade) error
+	grpc.ServerStream
+}
+
+type oceanbookInsertOCOOrderServer struct {
+	grpc.ServerStream
+}
+
+func (x *oceanbookInsertOCOOrderServer) Send(m *Trade) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Oceanbook_AmendOrder_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(AmendOrderRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(OceanbookServer).AmendOrder(m, &oceanbookAmendOrderServer{stream})
+}
+
+type Oceanbook_AmendOrderServer interface {
+	Send(*Trade) error
+	grpc.ServerStream
+}
+
+type oceanbookAmendOrderServer struct {
+	grpc.ServerStream
+}
+
+func (x *oceanbookAmendOrderServer) Send(m *Trade) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _Oceanbook_CancelOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CancelOrderRequest)
 	if err := dec(in); err != nil {
@@ -860,6 +3496,60 @@ func _Oceanbook_CancelOrder_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Oceanbook_MassCancel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MassCancelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OceanbookServer).MassCancel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/oceanbook.Oceanbook/MassCancel",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OceanbookServer).MassCancel(ctx, req.(*MassCancelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Oceanbook_GetOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OceanbookServer).GetOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/oceanbook.Oceanbook/GetOrder",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OceanbookServer).GetOrder(ctx, req.(*GetOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Oceanbook_ListOpenOrders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListOpenOrdersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OceanbookServer).ListOpenOrders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/oceanbook.Oceanbook/ListOpenOrders",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OceanbookServer).ListOpenOrders(ctx, req.(*ListOpenOrdersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Oceanbook_GetDepth_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetDepthRequest)
 	if err := dec(in); err != nil {
@@ -878,6 +3568,165 @@ func _Oceanbook_GetDepth_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Oceanbook_SubscribeDepth_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeDepthRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(OceanbookServer).SubscribeDepth(m, &oceanbookSubscribeDepthServer{stream})
+}
+
+type Oceanbook_SubscribeDepthServer interface {
+	Send(*DepthEvent) error
+	grpc.ServerStream
+}
+
+type oceanbookSubscribeDepthServer struct {
+	grpc.ServerStream
+}
+
+func (x *oceanbookSubscribeDepthServer) Send(m *DepthEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Oceanbook_SubscribeTrades_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeTradesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(OceanbookServer).SubscribeTrades(m, &oceanbookSubscribeTradesServer{stream})
+}
+
+type Oceanbook_SubscribeTradesServer interface {
+	Send(*Trade) error
+	grpc.ServerStream
+}
+
+type oceanbookSubscribeTradesServer struct {
+	grpc.ServerStream
+}
+
+func (x *oceanbookSubscribeTradesServer) Send(m *Trade) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Oceanbook_SubscribeOrderBook_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeOrderBookRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(OceanbookServer).SubscribeOrderBook(m, &oceanbookSubscribeOrderBookServer{stream})
+}
+
+type Oceanbook_SubscribeOrderBookServer interface {
+	Send(*OrderBookEvent) error
+	grpc.ServerStream
+}
+
+type oceanbookSubscribeOrderBookServer struct {
+	grpc.ServerStream
+}
+
+func (x *oceanbookSubscribeOrderBookServer) Send(m *OrderBookEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Oceanbook_SubscribeExecutionReports_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeExecutionReportsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(OceanbookServer).SubscribeExecutionReports(m, &oceanbookSubscribeExecutionReportsServer{stream})
+}
+
+type Oceanbook_SubscribeExecutionReportsServer interface {
+	Send(*ExecutionReport) error
+	grpc.ServerStream
+}
+
+type oceanbookSubscribeExecutionReportsServer struct {
+	grpc.ServerStream
+}
+
+func (x *oceanbookSubscribeExecutionReportsServer) Send(m *ExecutionReport) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Oceanbook_SetTradingState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetTradingStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OceanbookServer).SetTradingState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/oceanbook.Oceanbook/SetTradingState",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OceanbookServer).SetTradingState(ctx, req.(*SetTradingStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Oceanbook_GetTradingState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTradingStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OceanbookServer).GetTradingState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/oceanbook.Oceanbook/GetTradingState",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OceanbookServer).GetTradingState(ctx, req.(*GetTradingStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Oceanbook_StartAuction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartAuctionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OceanbookServer).StartAuction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/oceanbook.Oceanbook/StartAuction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OceanbookServer).StartAuction(ctx, req.(*StartAuctionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Oceanbook_Uncross_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(UncrossRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(OceanbookServer).Uncross(m, &oceanbookUncrossServer{stream})
+}
+
+type Oceanbook_UncrossServer interface {
+	Send(*Trade) error
+	grpc.ServerStream
+}
+
+type oceanbookUncrossServer struct {
+	grpc.ServerStream
+}
+
+func (x *oceanbookUncrossServer) Send(m *Trade) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 var _Oceanbook_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "oceanbook.Oceanbook",
 	HandlerType: (*OceanbookServer)(nil),
@@ -890,10 +3739,34 @@ var _Oceanbook_serviceDesc = grpc.ServiceDesc{
 			MethodName: "CancelOrder",
 			Handler:    _Oceanbook_CancelOrder_Handler,
 		},
+		{
+			MethodName: "MassCancel",
+			Handler:    _Oceanbook_MassCancel_Handler,
+		},
+		{
+			MethodName: "GetOrder",
+			Handler:    _Oceanbook_GetOrder_Handler,
+		},
+		{
+			MethodName: "ListOpenOrders",
+			Handler:    _Oceanbook_ListOpenOrders_Handler,
+		},
 		{
 			MethodName: "GetDepth",
 			Handler:    _Oceanbook_GetDepth_Handler,
 		},
+		{
+			MethodName: "SetTradingState",
+			Handler:    _Oceanbook_SetTradingState_Handler,
+		},
+		{
+			MethodName: "GetTradingState",
+			Handler:    _Oceanbook_GetTradingState_Handler,
+		},
+		{
+			MethodName: "StartAuction",
+			Handler:    _Oceanbook_StartAuction_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -901,6 +3774,41 @@ var _Oceanbook_serviceDesc = grpc.ServiceDesc{
 			Handler:       _Oceanbook_InsertOrder_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "InsertOCOOrder",
+			Handler:       _Oceanbook_InsertOCOOrder_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "AmendOrder",
+			Handler:       _Oceanbook_AmendOrder_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SubscribeDepth",
+			Handler:       _Oceanbook_SubscribeDepth_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SubscribeTrades",
+			Handler:       _Oceanbook_SubscribeTrades_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SubscribeOrderBook",
+			Handler:       _Oceanbook_SubscribeOrderBook_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SubscribeExecutionReports",
+			Handler:       _Oceanbook_SubscribeExecutionReports_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Uncross",
+			Handler:       _Oceanbook_Uncross_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "oceanbook.proto",
 }
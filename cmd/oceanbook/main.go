@@ -1,10 +1,12 @@
 package main
 
 import (
+	"context"
 	"net"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/draveness/oceanbook/api/protobuf-spec/oceanbookpb"
 	_ "github.com/draveness/oceanbook/pkg/log"
@@ -26,13 +28,17 @@ func main() {
 
 	grpcprometheus.Register(grpcServer)
 
-	var sigCh = make(chan os.Signal)
+	ctx, cancel := context.WithCancel(context.Background())
+	go svc.ExpireOrders(ctx, time.Second)
+
+	var sigCh = make(chan os.Signal, 1)
 	signal.Notify(sigCh, syscall.SIGTERM)
 	signal.Notify(sigCh, syscall.SIGINT)
 	go func() {
 		sig := <-sigCh
 		log.Infof("[oceanbook] received signal: %+v", sig)
 		log.Infof("[oceanbook] gracefully shutdown oceanbook server")
+		cancel()
 		grpcServer.GracefulStop()
 		log.Infof("[oceanbook] shutdown oceanbook server")
 		os.Exit(0)
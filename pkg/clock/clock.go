@@ -0,0 +1,57 @@
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock tells the current time to the matching engine.
+type Clock interface {
+	Now() time.Time
+}
+
+type realClock struct{}
+
+// New returns a clock which reads the system time.
+func New() Clock {
+	return realClock{}
+}
+
+// Now returns the system time.
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+// FakeClock is a manually advanced clock for testing and replaying.
+type FakeClock struct {
+	sync.RWMutex
+	now time.Time
+}
+
+// NewFakeClock returns a fake clock starting at now.
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{
+		now: now,
+	}
+}
+
+// Now returns the current time of fake clock.
+func (c *FakeClock) Now() time.Time {
+	c.RLock()
+	defer c.RUnlock()
+	return c.now
+}
+
+// Set moves fake clock to the time.
+func (c *FakeClock) Set(now time.Time) {
+	c.Lock()
+	defer c.Unlock()
+	c.now = now
+}
+
+// Add advances fake clock with the duration.
+func (c *FakeClock) Add(d time.Duration) {
+	c.Lock()
+	defer c.Unlock()
+	c.now = c.now.Add(d)
+}
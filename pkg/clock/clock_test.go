@@ -0,0 +1,21 @@
+package clock
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFakeClock(t *testing.T) {
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := NewFakeClock(now)
+
+	assert.Equal(t, now, clock.Now())
+
+	clock.Add(time.Second)
+	assert.Equal(t, now.Add(time.Second), clock.Now())
+
+	clock.Set(now)
+	assert.Equal(t, now, clock.Now())
+}
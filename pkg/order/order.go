@@ -3,8 +3,10 @@ package order
 import (
 	"time"
 
+	"github.com/draveness/oceanbook/api/protobuf-spec/oceanbookpb"
 	"github.com/draveness/oceanbook/pkg/trade"
 	"github.com/emirpasic/gods/utils"
+	"github.com/golang/protobuf/ptypes"
 	"github.com/shopspring/decimal"
 	log "github.com/sirupsen/logrus"
 )
@@ -20,16 +22,106 @@ const (
 	SideBid Side = "bid"
 )
 
+// Trigger is the market price movement which triggers stop order.
+type Trigger string
+
+const (
+	// TriggerRisesTo triggers stop order when last price rises to its stop price.
+	TriggerRisesTo Trigger = "rises_to"
+
+	// TriggerFallsTo triggers stop order when last price falls to its stop price.
+	TriggerFallsTo Trigger = "falls_to"
+)
+
+// StopType is the type of order placed when stop order triggered.
+type StopType string
+
+const (
+	// StopTypeLimit places a limit order with the price of stop order.
+	StopTypeLimit StopType = "stop_limit"
+
+	// StopTypeMarket places a market order.
+	StopTypeMarket StopType = "stop_market"
+)
+
+// SelfTradePrevention decides which orders are cancelled when orders from the
+// same owner match.
+type SelfTradePrevention string
+
+const (
+	// SelfTradePreventionCancelNewest cancels the taker order.
+	SelfTradePreventionCancelNewest SelfTradePrevention = "cancel_newest"
+
+	// SelfTradePreventionCancelOldest cancels the maker order.
+	SelfTradePreventionCancelOldest SelfTradePrevention = "cancel_oldest"
+
+	// SelfTradePreventionCancelBoth cancels both taker and maker orders.
+	SelfTradePreventionCancelBoth SelfTradePrevention = "cancel_both"
+
+	// SelfTradePreventionDecrementAndCancel decreases both orders by the smaller
+	// pending quantity and cancels the order without pending quantity.
+	SelfTradePreventionDecrementAndCancel SelfTradePrevention = "decrement_and_cancel"
+)
+
+// State is the lifecycle state of order.
+type State string
+
+const (
+	// StatePending represents the new order accepted by orderbook.
+	StatePending State = "pending"
+
+	// StatePartiallyFilled represents the order with part of its quantity filled.
+	StatePartiallyFilled State = "partially_filled"
+
+	// StateFilled represents the order with all its quantity filled.
+	StateFilled State = "filled"
+
+	// StateCancelled represents the order removed before it is filled.
+	StateCancelled State = "cancelled"
+
+	// StateRejected represents the order never accepted by orderbook.
+	StateRejected State = "rejected"
+
+	// StateTriggered represents the stop order triggered by market price.
+	StateTriggered State = "triggered"
+
+	// StateExpired represents the order removed at its expiry time.
+	StateExpired State = "expired"
+)
+
 // Order .
 type Order struct {
 	ID                uint64          `json:"id"`
 	Side              Side            `json:"side"`
 	Price             decimal.Decimal `json:"price"`
 	StopPrice         decimal.Decimal `json:"stop_price"`
+	Trigger           Trigger         `json:"trigger"`
+	StopType          StopType        `json:"stop_type"`
+	TrailingAmount    decimal.Decimal `json:"trailing_amount"`
+	TrailingPercent   decimal.Decimal `json:"trailing_percent"`
 	Quantity          decimal.Decimal `json:"quantity"`
 	FilledQuantity    decimal.Decimal `json:"filled_quantity"`
+	QuoteQuantity     decimal.Decimal `json:"quote_quantity"`
+	FilledQuote       decimal.Decimal `json:"filled_quote"`
 	CreatedAt         time.Time       `json:"created_at"`
+	ExpiresAt         time.Time       `json:"expires_at"`
 	ImmediateOrCancel bool            `json:"immediate_or_cancel"`
+	FillOrKill        bool            `json:"fill_or_kill"`
+	PostOnly          bool            `json:"post_only"`
+	PostOnlySlide     bool            `json:"post_only_slide"`
+	DisplayQuantity   decimal.Decimal `json:"display_quantity"`
+
+	OwnerID             uint64              `json:"owner_id"`
+	SelfTradePrevention SelfTradePrevention `json:"self_trade_prevention"`
+
+	// GroupID links one-cancels-other orders, zero means the order is not linked.
+	GroupID uint64 `json:"group_id"`
+
+	// State is the lifecycle state of order, empty before it is inserted.
+	State State `json:"state"`
+
+	// visible is the remaining quantity of the displayed iceberg slice.
+	visible decimal.Decimal
 }
 
 // Key is used to sort orders in red black tree.
@@ -38,7 +130,9 @@ type Key struct {
 	Side      Side            `json:"side"`
 	Price     decimal.Decimal `json:"price"`
 	StopPrice decimal.Decimal `json:"stop_price"`
+	Trigger   Trigger         `json:"trigger"`
 	CreatedAt time.Time       `json:"created_at"`
+	ExpiresAt time.Time       `json:"expires_at"`
 }
 
 // Key returns a Key.
@@ -47,7 +141,10 @@ func (o *Order) Key() *Key {
 		ID:        o.ID,
 		Side:      o.Side,
 		Price:     o.Price,
+		StopPrice: o.StopPrice,
+		Trigger:   o.TriggerDirection(),
 		CreatedAt: o.CreatedAt,
+		ExpiresAt: o.ExpiresAt,
 	}
 }
 
@@ -61,9 +158,65 @@ func (o *Order) PendingQuantity() decimal.Decimal {
 	return o.Quantity.Sub(o.FilledQuantity)
 }
 
+// IsQuoteQuantity returns true when the market order is sized by the amount of
+// quote asset to spend instead of the base quantity.
+func (o *Order) IsQuoteQuantity() bool {
+	return o.QuoteQuantity.IsPositive()
+}
+
+// AffordableQuantity returns the base quantity the remaining quote amount is
+// able to buy at the price, rounded down to the multiple of lot size.
+func (o *Order) AffordableQuantity(price, lotSize decimal.Decimal) decimal.Decimal {
+	if !price.IsPositive() {
+		return decimal.Zero
+	}
+
+	quantity := o.QuoteQuantity.Sub(o.FilledQuote).Div(price)
+	if lotSize.IsPositive() {
+		quantity = quantity.Div(lotSize).Floor().Mul(lotSize)
+	}
+
+	return decimal.Max(quantity, decimal.Zero)
+}
+
+// Expired returns true when the order has an expiry time not after now.
+func (o *Order) Expired(now time.Time) bool {
+	return !o.ExpiresAt.IsZero() && !now.Before(o.ExpiresAt)
+}
+
 // Fill updates order filled quantity with passing arguments.
 func (o *Order) Fill(quantity decimal.Decimal) {
 	o.FilledQuantity = o.FilledQuantity.Add(quantity)
+
+	if o.IsIceberg() {
+		o.visible = decimal.Max(o.visible.Sub(quantity), decimal.Zero)
+	}
+}
+
+// Decrease lowers the order quantity, and the displayed slice of iceberg order
+// never exceeds its pending quantity.
+func (o *Order) Decrease(quantity decimal.Decimal) {
+	o.Quantity = o.Quantity.Sub(quantity)
+	o.visible = decimal.Min(o.visible, o.PendingQuantity())
+}
+
+// IsIceberg returns true when the order only displays part of its quantity.
+func (o *Order) IsIceberg() bool {
+	return o.DisplayQuantity.IsPositive() && o.DisplayQuantity.LessThan(o.Quantity)
+}
+
+// VisibleQuantity is the quantity displayed in the orderbook.
+func (o *Order) VisibleQuantity() decimal.Decimal {
+	if !o.IsIceberg() {
+		return o.PendingQuantity()
+	}
+
+	return o.visible
+}
+
+// Replenish refreshes the displayed slice of iceberg order from its reserve.
+func (o *Order) Replenish() {
+	o.visible = decimal.Min(o.DisplayQuantity, o.PendingQuantity())
 }
 
 // IsLimit returns true when the order is limit order.
@@ -76,58 +229,120 @@ func (o *Order) IsMarket() bool {
 	return o.Price.IsZero()
 }
 
-// Match matches maker with a taker and returns trade if there is a match.
-func (o *Order) Match(taker *Order) *trade.Trade {
-	maker := o
-	if maker.Side == taker.Side {
-		log.Fatalf("[oceanbook.orderbook] match order with same side %s, %d, %d", maker.Side, maker.ID, taker.ID)
-		return nil
-	}
+// IsStop returns true when the order is a stop order.
+func (o *Order) IsStop() bool {
+	return !o.StopPrice.IsZero()
+}
 
-	var bidOrder *Order
-	var askOrder *Order
+// TriggerDirection returns the price movement which triggers the stop order.
+// Stop bid order is triggered by falling price and stop ask order is
+// triggered by rising price when its trigger is not specified.
+func (o *Order) TriggerDirection() Trigger {
+	if o.Trigger != "" {
+		return o.Trigger
+	}
 
-	switch maker.Side {
+	switch o.Side {
 	case SideBid:
-		bidOrder = maker
-		askOrder = taker
+		return TriggerFallsTo
 
 	case SideAsk:
-		bidOrder = taker
-		askOrder = maker
+		return TriggerRisesTo
+	}
+
+	return ""
+}
+
+// Triggered returns true when the market price reaches the stop price.
+func (o *Order) Triggered(price decimal.Decimal) bool {
+	switch o.TriggerDirection() {
+	case TriggerRisesTo:
+		return price.GreaterThanOrEqual(o.StopPrice)
+
+	case TriggerFallsTo:
+		return price.LessThanOrEqual(o.StopPrice)
+	}
+
+	return false
+}
+
+// IsTrailing returns true when the order is a trailing stop order.
+func (o *Order) IsTrailing() bool {
+	return o.TrailingAmount.IsPositive() || o.TrailingPercent.IsPositive()
+}
+
+// TrailingStopPrice returns the stop price which trails the market price by
+// trailing amount or percentage.
+func (o *Order) TrailingStopPrice(price decimal.Decimal) decimal.Decimal {
+	offset := o.TrailingAmount
+	if o.TrailingPercent.IsPositive() {
+		offset = price.Mul(o.TrailingPercent).Div(decimal.New(100, 0))
+	}
+
+	if o.TriggerDirection() == TriggerRisesTo {
+		return price.Add(offset)
 	}
 
+	return price.Sub(offset)
+}
+
+// Crosses returns true when the taker accepts the price of maker.
+func (o *Order) Crosses(taker *Order) bool {
+	maker := o
+
 	switch {
-	case taker.IsLimit():
-		if bidOrder.Price.GreaterThanOrEqual(askOrder.Price) {
-			filledQuantity := decimal.Min(bidOrder.PendingQuantity(), askOrder.PendingQuantity())
-			bidOrder.Fill(filledQuantity)
-			askOrder.Fill(filledQuantity)
-
-			return &trade.Trade{
-				Price:    maker.Price,
-				Quantity: filledQuantity,
-				TakerID:  taker.ID,
-				MakerID:  maker.ID,
-			}
-		}
+	case taker.IsMarket():
+		return true
+
+	case taker.IsLimit() && maker.Side == SideBid:
+		return maker.Price.GreaterThanOrEqual(taker.Price)
 
+	case taker.IsLimit() && maker.Side == SideAsk:
+		return maker.Price.LessThanOrEqual(taker.Price)
+	}
+
+	return false
+}
+
+// IsSelfTrade returns true when maker and taker belong to the same owner.
+func (o *Order) IsSelfTrade(taker *Order) bool {
+	return o.OwnerID != 0 && o.OwnerID == taker.OwnerID
+}
+
+// Match matches maker with a taker and returns trade if there is a match.
+func (o *Order) Match(taker *Order) *trade.Trade {
+	return o.MatchQuantity(taker, taker.PendingQuantity())
+}
+
+// MatchQuantity matches maker with a taker no more than the quantity and
+// returns trade if there is a match.
+func (o *Order) MatchQuantity(taker *Order, quantity decimal.Decimal) *trade.Trade {
+	maker := o
+	if maker.Side == taker.Side {
+		log.Fatalf("[oceanbook.orderbook] match order with same side %s, %d, %d", maker.Side, maker.ID, taker.ID)
 		return nil
+	}
 
-	case taker.IsMarket():
-		filledQuantity := decimal.Min(bidOrder.PendingQuantity(), askOrder.PendingQuantity())
-		bidOrder.Fill(filledQuantity)
-		askOrder.Fill(filledQuantity)
-
-		return &trade.Trade{
-			Price:    maker.Price,
-			Quantity: filledQuantity,
-			TakerID:  taker.ID,
-			MakerID:  maker.ID,
-		}
+	if !maker.Crosses(taker) {
+		return nil
 	}
 
-	return nil
+	filledQuantity := decimal.Min(maker.VisibleQuantity(), taker.PendingQuantity(), quantity)
+	maker.Fill(filledQuantity)
+	taker.Fill(filledQuantity)
+
+	filledQuote := filledQuantity.Mul(maker.Price)
+	maker.FilledQuote = maker.FilledQuote.Add(filledQuote)
+	taker.FilledQuote = taker.FilledQuote.Add(filledQuote)
+
+	return &trade.Trade{
+		Price:        maker.Price,
+		Quantity:     filledQuantity,
+		TakerID:      taker.ID,
+		MakerID:      maker.ID,
+		TakerOwnerID: taker.OwnerID,
+		MakerOwnerID: maker.OwnerID,
+	}
 }
 
 // Comparator is used for comparing Key.
@@ -173,7 +388,10 @@ func Comparator(a, b interface{}) (result int) {
 	return
 }
 
-// StopComparator is used for comparing Key.
+// StopComparator is used for comparing Key of stop orders. Orders triggered by
+// falling price are placed before orders triggered by rising price, so the
+// left most order is the next one triggered by falling price and the right
+// most order is the next one triggered by rising price.
 func StopComparator(a, b interface{}) (result int) {
 	this := a.(*Key)
 	that := b.(*Key)
@@ -186,32 +404,130 @@ func StopComparator(a, b interface{}) (result int) {
 		return
 	}
 
-	// based on ask
 	switch {
-	case this.Side == SideAsk && this.StopPrice.LessThan(that.StopPrice):
-		result = 1
-
-	case this.Side == SideAsk && this.StopPrice.GreaterThan(that.StopPrice):
+	case this.Trigger != that.Trigger && this.Trigger == TriggerFallsTo:
 		result = -1
 
-	case this.Side == SideBid && this.StopPrice.LessThan(that.StopPrice):
+	case this.Trigger != that.Trigger:
+		result = 1
+
+	case this.StopPrice.GreaterThan(that.StopPrice):
 		result = -1
 
-	case this.Side == SideBid && this.StopPrice.GreaterThan(that.StopPrice):
+	case this.StopPrice.LessThan(that.StopPrice):
 		result = 1
 
 	default:
 		switch {
 		case this.CreatedAt.Before(that.CreatedAt):
-			result = 1
+			result = -1
 
 		case this.CreatedAt.After(that.CreatedAt):
-			result = -1
+			result = 1
 
 		default:
-			result = utils.UInt64Comparator(this.ID, that.ID) * -1
+			result = utils.UInt64Comparator(this.ID, that.ID)
+		}
+
+		if this.Trigger == TriggerRisesTo {
+			result *= -1
 		}
 	}
 
 	return
 }
+
+// ExpiryComparator is used for comparing Key by expiry time.
+func ExpiryComparator(a, b interface{}) (result int) {
+	this := a.(*Key)
+	that := b.(*Key)
+
+	if this.ID == that.ID {
+		return
+	}
+
+	switch {
+	case this.ExpiresAt.Before(that.ExpiresAt):
+		result = -1
+
+	case this.ExpiresAt.After(that.ExpiresAt):
+		result = 1
+
+	default:
+		result = utils.UInt64Comparator(this.ID, that.ID)
+	}
+
+	return
+}
+
+var (
+	serializedSides = map[Side]oceanbookpb.Order_Side{
+		SideAsk: oceanbookpb.Order_ASK,
+		SideBid: oceanbookpb.Order_BID,
+	}
+
+	serializedStates = map[State]oceanbookpb.Order_State{
+		StatePending:         oceanbookpb.Order_PENDING,
+		StatePartiallyFilled: oceanbookpb.Order_PARTIALLY_FILLED,
+		StateFilled:          oceanbookpb.Order_FILLED,
+		StateCancelled:       oceanbookpb.Order_CANCELLED,
+		StateRejected:        oceanbookpb.Order_REJECTED,
+		StateTriggered:       oceanbookpb.Order_TRIGGERED,
+		StateExpired:         oceanbookpb.Order_EXPIRED,
+	}
+
+	serializedTriggers = map[Trigger]oceanbookpb.Order_Trigger{
+		TriggerRisesTo: oceanbookpb.Order_RISES_TO,
+		TriggerFallsTo: oceanbookpb.Order_FALLS_TO,
+	}
+
+	serializedStopTypes = map[StopType]oceanbookpb.Order_StopType{
+		StopTypeLimit:  oceanbookpb.Order_STOP_LIMIT,
+		StopTypeMarket: oceanbookpb.Order_STOP_MARKET,
+	}
+
+	serializedSelfTradePreventions = map[SelfTradePrevention]oceanbookpb.Order_SelfTradePrevention{
+		SelfTradePreventionCancelNewest:       oceanbookpb.Order_CANCEL_NEWEST,
+		SelfTradePreventionCancelOldest:       oceanbookpb.Order_CANCEL_OLDEST,
+		SelfTradePreventionCancelBoth:         oceanbookpb.Order_CANCEL_BOTH,
+		SelfTradePreventionDecrementAndCancel: oceanbookpb.Order_DECREMENT_AND_CANCEL,
+	}
+)
+
+// Serialize returns protobuf encoded side.
+func (s Side) Serialize() oceanbookpb.Order_Side {
+	return serializedSides[s]
+}
+
+// Serialize returns protobuf encoded order.
+func (o *Order) Serialize() *oceanbookpb.Order {
+	serialized := &oceanbookpb.Order{
+		Id:                  o.ID,
+		Price:               o.Price.String(),
+		Quantity:            o.Quantity.String(),
+		Side:                serializedSides[o.Side],
+		State:               serializedStates[o.State],
+		StopPrice:           o.StopPrice.String(),
+		ImmediateOrCancel:   o.ImmediateOrCancel,
+		FillOrKill:          o.FillOrKill,
+		PostOnly:            o.PostOnly,
+		PostOnlySlide:       o.PostOnlySlide,
+		DisplayQuantity:     o.DisplayQuantity.String(),
+		OwnerId:             o.OwnerID,
+		SelfTradePrevention: serializedSelfTradePreventions[o.SelfTradePrevention],
+		Trigger:             serializedTriggers[o.Trigger],
+		StopType:            serializedStopTypes[o.StopType],
+		TrailingAmount:      o.TrailingAmount.String(),
+		TrailingPercent:     o.TrailingPercent.String(),
+		GroupId:             o.GroupID,
+		QuoteQuantity:       o.QuoteQuantity.String(),
+		FilledQuantity:      o.FilledQuantity.String(),
+		RemainingQuantity:   o.PendingQuantity().String(),
+	}
+
+	if !o.ExpiresAt.IsZero() {
+		serialized.ExpiresAt, _ = ptypes.TimestampProto(o.ExpiresAt)
+	}
+
+	return serialized
+}
@@ -63,6 +63,81 @@ func (s *suiteMatchOrderTester) TestMatchOrderNoMatch() {
 	s.Nil(trade)
 }
 
+func (s *suiteMatchOrderTester) TestCrosses() {
+	askOrder := &Order{
+		ID:       1,
+		Side:     SideAsk,
+		Price:    decimal.NewFromFloat(2.0),
+		Quantity: decimal.NewFromFloat(3.0),
+	}
+
+	s.True(askOrder.Crosses(&Order{ID: 2, Side: SideBid, Price: decimal.NewFromFloat(2.0)}))
+	s.True(askOrder.Crosses(&Order{ID: 3, Side: SideBid, Price: decimal.Zero}))
+	s.False(askOrder.Crosses(&Order{ID: 4, Side: SideBid, Price: decimal.NewFromFloat(1.9)}))
+}
+
+func (s *suiteMatchOrderTester) TestMatchIcebergOrder() {
+	askOrder := &Order{
+		ID:              1,
+		Side:            SideAsk,
+		Price:           decimal.NewFromFloat(2.0),
+		Quantity:        decimal.NewFromFloat(10.0),
+		DisplayQuantity: decimal.NewFromFloat(4.0),
+	}
+	askOrder.Replenish()
+
+	bidOrder := &Order{
+		ID:       2,
+		Side:     SideBid,
+		Price:    decimal.NewFromFloat(2.0),
+		Quantity: decimal.NewFromFloat(5.0),
+	}
+
+	t := askOrder.Match(bidOrder)
+
+	s.True(decimal.NewFromFloat(4.0).Equal(t.Quantity))
+	s.True(askOrder.VisibleQuantity().IsZero())
+	s.True(decimal.NewFromFloat(6.0).Equal(askOrder.PendingQuantity()))
+
+	askOrder.Replenish()
+	s.True(decimal.NewFromFloat(4.0).Equal(askOrder.VisibleQuantity()))
+}
+
+func (s *suiteMatchOrderTester) TestTrailingStopPrice() {
+	askOrder := &Order{
+		ID:              1,
+		Side:            SideAsk,
+		Trigger:         TriggerFallsTo,
+		TrailingPercent: decimal.NewFromFloat(5.0),
+	}
+
+	bidOrder := &Order{
+		ID:             2,
+		Side:           SideBid,
+		Trigger:        TriggerRisesTo,
+		TrailingAmount: decimal.NewFromFloat(2.0),
+	}
+
+	s.True(askOrder.IsTrailing())
+	s.True(decimal.NewFromFloat(95.0).Equal(askOrder.TrailingStopPrice(decimal.NewFromFloat(100.0))))
+	s.True(decimal.NewFromFloat(102.0).Equal(bidOrder.TrailingStopPrice(decimal.NewFromFloat(100.0))))
+}
+
+func (s *suiteMatchOrderTester) TestAffordableQuantity() {
+	bidOrder := &Order{
+		ID:            1,
+		Side:          SideBid,
+		QuoteQuantity: decimal.NewFromFloat(100.0),
+		FilledQuote:   decimal.NewFromFloat(30.0),
+	}
+
+	s.True(bidOrder.IsQuoteQuantity())
+	s.True(decimal.NewFromFloat(2.33).Equal(bidOrder.AffordableQuantity(decimal.NewFromFloat(30.0), decimal.NewFromFloat(0.01))))
+	s.True(decimal.NewFromFloat(2.0).Equal(bidOrder.AffordableQuantity(decimal.NewFromFloat(30.0), decimal.NewFromFloat(1.0))))
+	s.True(decimal.NewFromFloat(7.0).Equal(bidOrder.AffordableQuantity(decimal.NewFromFloat(10.0), decimal.Zero)))
+	s.True(bidOrder.AffordableQuantity(decimal.NewFromFloat(80.0), decimal.NewFromFloat(1.0)).IsZero())
+}
+
 func TestMatchOrder(t *testing.T) {
 	tester := new(suiteMatchOrderTester)
 	suite.Run(t, tester)
@@ -113,6 +188,49 @@ func (s *suiteComparatorTester) TestBidOrderComparator() {
 	s.Equal([]Order{b4, b2, b1, b3}, orderValues)
 }
 
+func (s *suiteComparatorTester) TestStopComparator() {
+	s1 := Order{
+		ID:        1,
+		Side:      SideAsk,
+		StopPrice: decimal.NewFromFloat(1.0),
+		Trigger:   TriggerFallsTo,
+	}
+
+	s2 := Order{
+		ID:        2,
+		Side:      SideAsk,
+		StopPrice: decimal.NewFromFloat(2.0),
+		Trigger:   TriggerFallsTo,
+	}
+
+	s3 := Order{
+		ID:        3,
+		Side:      SideAsk,
+		StopPrice: decimal.NewFromFloat(3.0),
+		Trigger:   TriggerRisesTo,
+	}
+
+	s4 := Order{
+		ID:        4,
+		Side:      SideAsk,
+		StopPrice: decimal.NewFromFloat(4.0),
+		Trigger:   TriggerRisesTo,
+	}
+
+	tree := rbt.NewWith(StopComparator)
+	tree.Put(s1.Key(), s1)
+	tree.Put(s2.Key(), s2)
+	tree.Put(s3.Key(), s3)
+	tree.Put(s4.Key(), s4)
+
+	var orderValues []Order
+	for _, value := range tree.Values() {
+		orderValues = append(orderValues, value.(Order))
+	}
+
+	s.Equal([]Order{s2, s1, s4, s3}, orderValues)
+}
+
 func TestComparator(t *testing.T) {
 	tester := new(suiteComparatorTester)
 	suite.Run(t, tester)
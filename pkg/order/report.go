@@ -0,0 +1,35 @@
+package order
+
+import (
+	"github.com/draveness/oceanbook/api/protobuf-spec/oceanbookpb"
+	"github.com/draveness/oceanbook/pkg/trade"
+)
+
+// Report is the execution report of a trade or a state change of order.
+type Report struct {
+	// Trade is the executed trade, and it is nil for state changes of order.
+	Trade *trade.Trade
+
+	// Order is the snapshot of order when its state changes.
+	Order *Order
+
+	// Reason explains why the order is rejected or cancelled.
+	Reason string
+}
+
+// Serialize returns protobuf encoded execution report.
+func (r *Report) Serialize() *oceanbookpb.ExecutionReport {
+	serialized := &oceanbookpb.ExecutionReport{
+		Reason: r.Reason,
+	}
+
+	if r.Trade != nil {
+		serialized.Trade = r.Trade.Serialize()
+	}
+
+	if r.Order != nil {
+		serialized.Order = r.Order.Serialize()
+	}
+
+	return serialized
+}
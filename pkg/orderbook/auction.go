@@ -0,0 +1,227 @@
+package orderbook
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/draveness/oceanbook/pkg/order"
+	"github.com/draveness/oceanbook/pkg/trade"
+	rbt "github.com/emirpasic/gods/trees/redblacktree"
+	"github.com/shopspring/decimal"
+	log "github.com/sirupsen/logrus"
+)
+
+var (
+	// ErrInvalidAuctionOrder returns when the order is not able to rest in the
+	// orderbook during the auction, such as market, immediate or cancel and
+	// fill or kill orders.
+	ErrInvalidAuctionOrder = errors.New("invalid auction order")
+
+	// ErrAuctionNotStarted returns when uncrossing an orderbook not in auction.
+	ErrAuctionNotStarted = errors.New("auction not started")
+)
+
+// StartAuction starts the call auction by transiting the orderbook to pre-open
+// state, orders accumulate in the orderbook without matching until it is
+// uncrossed. It also opens the orderbook and re-opens it after halt.
+func (od *OrderBook) StartAuction() {
+	od.Lock()
+	defer od.Unlock()
+
+	log.Infof("[oceanbook.orderbook] orderbook %s starts auction from %s", od.Symbol, od.state)
+
+	od.state = TradingStatePreOpen
+}
+
+// Uncross ends the call auction, executes crossed orders at the equilibrium
+// price and transits the orderbook to continuous state.
+func (od *OrderBook) Uncross() ([]*trade.Trade, error) {
+	od.Lock()
+	defer od.Unlock()
+
+	if od.state != TradingStatePreOpen {
+		return []*trade.Trade{}, ErrAuctionNotStarted
+	}
+
+	od.expireOrders()
+	od.state = TradingStateContinuous
+
+	price, volume := equilibriumPrice(od.Bids, od.Asks, od.Price)
+	if !volume.IsPositive() {
+		log.Infof("[oceanbook.orderbook] orderbook %s uncrossed without trades", od.Symbol)
+		return []*trade.Trade{}, nil
+	}
+
+	log.Infof("[oceanbook.orderbook] orderbook %s uncrossed at price %s with volume %s", od.Symbol, price, volume)
+
+	trades := []*trade.Trade{}
+	for {
+		best, worst := od.Bids.Right(), od.Asks.Right()
+		if best == nil || worst == nil {
+			break
+		}
+
+		bidOrder := best.Value.(*order.Order)
+		askOrder := worst.Value.(*order.Order)
+		if bidOrder.Price.LessThan(price) || askOrder.Price.GreaterThan(price) {
+			break
+		}
+
+		if bidOrder.IsSelfTrade(askOrder) {
+			od.preventAuctionSelfTrade(bidOrder, askOrder)
+			continue
+		}
+
+		quantity := decimal.Min(bidOrder.PendingQuantity(), askOrder.PendingQuantity())
+		od.fillAuctionOrder(od.Bids, bidOrder, quantity)
+		od.fillAuctionOrder(od.Asks, askOrder, quantity)
+
+		// bid order is recorded as the taker since there is no aggressor in
+		// the auction.
+		newTrade := &trade.Trade{
+			Price:        price,
+			Quantity:     quantity,
+			TakerID:      bidOrder.ID,
+			MakerID:      askOrder.ID,
+			TakerOwnerID: bidOrder.OwnerID,
+			MakerOwnerID: askOrder.OwnerID,
+		}
+		od.stampTrade(newTrade)
+
+		trades = append(trades, newTrade)
+		od.reportTrade(newTrade, askOrder, bidOrder)
+
+		od.cancelLinkedOrders(bidOrder)
+		od.cancelLinkedOrders(askOrder)
+	}
+
+	od.setMarketPrice(price)
+	od.resetReferencePrice()
+
+	return append(trades, od.insertPendingOrders()...), nil
+}
+
+// preventAuctionSelfTrade cancels or decreases the crossed orders from the same
+// owner at uncross by the self trade prevention mode of the newer order, which
+// is regarded as the taker.
+func (od *OrderBook) preventAuctionSelfTrade(bidOrder, askOrder *order.Order) {
+	older, newer := bidOrder, askOrder
+	if askOrder.CreatedAt.Before(bidOrder.CreatedAt) || (askOrder.CreatedAt.Equal(bidOrder.CreatedAt) && askOrder.ID < bidOrder.ID) {
+		older, newer = askOrder, bidOrder
+	}
+
+	log.Debugf("[oceanbook.orderbook] prevent self trade between %d and %d at uncross with mode %s", older.ID, newer.ID, newer.SelfTradePrevention)
+
+	switch newer.SelfTradePrevention {
+	case order.SelfTradePreventionCancelOldest:
+		od.cancelSelfTradeOrder(older)
+
+	case order.SelfTradePreventionCancelBoth:
+		od.cancelSelfTradeOrder(older)
+		od.cancelSelfTradeOrder(newer)
+
+	case order.SelfTradePreventionDecrementAndCancel:
+		quantity := decimal.Min(older.PendingQuantity(), newer.PendingQuantity())
+		od.decrementSelfTradeOrder(older, quantity)
+		od.decrementSelfTradeOrder(newer, quantity)
+
+	default:
+		od.cancelSelfTradeOrder(newer)
+	}
+}
+
+// fillAuctionOrder fills the order at uncross and removes it from orderbook
+// when it is filled, or replenishes its displayed slice as a new order in the
+// order-by-order feed.
+func (od *OrderBook) fillAuctionOrder(books *rbt.Tree, o *order.Order, quantity decimal.Decimal) {
+	visibleQuantity := o.VisibleQuantity()
+	o.Fill(quantity)
+	od.publishExecute(o, quantity)
+
+	switch {
+	case o.Filled():
+		books.Remove(o.Key())
+		delete(od.cancelOrdersQueue, o.ID)
+		od.expiries.Remove(o.Key())
+		od.depth.update(o.Side, o.Price, visibleQuantity.Neg(), -1)
+
+	case o.VisibleQuantity().IsZero():
+		od.replenishOrder(books, o)
+		od.publishAdd(books, o)
+		fallthrough
+
+	default:
+		od.depth.update(o.Side, o.Price, o.VisibleQuantity().Sub(visibleQuantity), 0)
+	}
+}
+
+// equilibriumPrice returns the price which maximises executable volume of
+// crossed orders. Ties are broken by the minimum imbalance between bid and ask
+// volume, then by the distance to reference price, and the lower price wins
+// the remaining ties.
+func equilibriumPrice(bids, asks *rbt.Tree, reference decimal.Decimal) (decimal.Decimal, decimal.Decimal) {
+	bidQuantities := map[string]decimal.Decimal{}
+	askQuantities := map[string]decimal.Decimal{}
+	prices := []decimal.Decimal{}
+
+	for _, books := range []*rbt.Tree{bids, asks} {
+		quantities := bidQuantities
+		if books == asks {
+			quantities = askQuantities
+		}
+
+		it := books.Iterator()
+		for it.Next() {
+			o := it.Value().(*order.Order)
+			key := o.Price.String()
+
+			if _, ok := bidQuantities[key]; !ok {
+				if _, ok := askQuantities[key]; !ok {
+					prices = append(prices, o.Price)
+				}
+			}
+
+			quantities[key] = quantities[key].Add(o.PendingQuantity())
+		}
+	}
+
+	sort.Slice(prices, func(i, j int) bool {
+		return prices[i].LessThan(prices[j])
+	})
+
+	// askVolumes are cumulative quantities of asks at or below each price, and
+	// bidVolumes are cumulative quantities of bids at or above each price.
+	askVolumes := make([]decimal.Decimal, len(prices))
+	bidVolumes := make([]decimal.Decimal, len(prices))
+	askVolume, bidVolume := decimal.Zero, decimal.Zero
+	for i := range prices {
+		askVolume = askVolume.Add(askQuantities[prices[i].String()])
+		askVolumes[i] = askVolume
+
+		j := len(prices) - 1 - i
+		bidVolume = bidVolume.Add(bidQuantities[prices[j].String()])
+		bidVolumes[j] = bidVolume
+	}
+
+	price, volume, imbalance := decimal.Zero, decimal.Zero, decimal.Zero
+	for i, candidate := range prices {
+		candidateVolume := decimal.Min(bidVolumes[i], askVolumes[i])
+		candidateImbalance := bidVolumes[i].Sub(askVolumes[i]).Abs()
+
+		switch {
+		case candidateVolume.GreaterThan(volume):
+		case candidateVolume.LessThan(volume) || !candidateVolume.IsPositive():
+			continue
+		case candidateImbalance.LessThan(imbalance):
+		case candidateImbalance.GreaterThan(imbalance):
+			continue
+		case reference.IsPositive() && candidate.Sub(reference).Abs().LessThan(price.Sub(reference).Abs()):
+		default:
+			continue
+		}
+
+		price, volume, imbalance = candidate, candidateVolume, candidateImbalance
+	}
+
+	return price, volume
+}
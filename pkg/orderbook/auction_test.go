@@ -0,0 +1,49 @@
+package orderbook
+
+import (
+	"testing"
+
+	"github.com/draveness/oceanbook/pkg/order"
+	rbt "github.com/emirpasic/gods/trees/redblacktree"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEquilibriumPrice(t *testing.T) {
+	newBooks := func(side order.Side, levels [][2]float64) *rbt.Tree {
+		books := rbt.NewWith(order.Comparator)
+		for i, level := range levels {
+			o := &order.Order{
+				ID:       uint64(i + 1),
+				Side:     side,
+				Price:    decimal.NewFromFloat(level[0]),
+				Quantity: decimal.NewFromFloat(level[1]),
+			}
+			books.Put(o.Key(), o)
+		}
+
+		return books
+	}
+
+	tests := []struct {
+		name      string
+		bids      [][2]float64
+		asks      [][2]float64
+		reference float64
+		price     float64
+		volume    float64
+	}{
+		{"maximum volume", [][2]float64{{10.2, 3}, {10.1, 2}, {10.0, 5}}, [][2]float64{{9.9, 2}, {10.0, 4}, {10.1, 4}}, 0, 10.0, 6},
+		{"minimum imbalance", [][2]float64{{10.0, 4}}, [][2]float64{{9.0, 4}, {10.0, 2}}, 0, 9.0, 4},
+		{"closest to reference", [][2]float64{{10.0, 5}}, [][2]float64{{9.0, 5}}, 9.8, 10.0, 5},
+		{"lower price", [][2]float64{{10.0, 5}}, [][2]float64{{9.0, 5}}, 0, 9.0, 5},
+		{"not crossed", [][2]float64{{9.0, 5}}, [][2]float64{{10.0, 5}}, 0, 0, 0},
+	}
+
+	for _, test := range tests {
+		price, volume := equilibriumPrice(newBooks(order.SideBid, test.bids), newBooks(order.SideAsk, test.asks), decimal.NewFromFloat(test.reference))
+
+		assert.True(t, decimal.NewFromFloat(test.price).Equal(price), "%s: price %s", test.name, price)
+		assert.True(t, decimal.NewFromFloat(test.volume).Equal(volume), "%s: volume %s", test.name, volume)
+	}
+}
@@ -0,0 +1,121 @@
+package orderbook
+
+import (
+	"errors"
+	"time"
+
+	"github.com/shopspring/decimal"
+	log "github.com/sirupsen/logrus"
+)
+
+var (
+	// ErrCircuitBreakerTripped returns when a trade would occur outside the
+	// price band, continuous trading is halted and the remainder of the order
+	// is cancelled.
+	ErrCircuitBreakerTripped = errors.New("circuit breaker tripped")
+)
+
+// CircuitBreaker halts continuous trading when a trade would occur outside
+// the price band around the reference price.
+type CircuitBreaker struct {
+	// Band is the maximum percentage deviation of trade price from the
+	// reference price.
+	Band decimal.Decimal
+
+	// Window is the sliding window of dynamic band whose reference price is
+	// the earliest trade price within the window. Static band with zero
+	// window uses the price when continuous trading starts.
+	Window time.Duration
+
+	// ReopenByAuction transits the orderbook to pre-open state instead of
+	// halted state when the circuit breaker trips, and the orderbook is
+	// re-opened by uncross.
+	ReopenByAuction bool
+}
+
+// Enabled returns true when trades are checked by the price band.
+func (cb CircuitBreaker) Enabled() bool {
+	return cb.Band.IsPositive()
+}
+
+// referencePrice is the trade price at the time.
+type referencePrice struct {
+	price     decimal.Decimal
+	createdAt time.Time
+}
+
+// breaksCircuit returns true when the trade price is outside the price band.
+func (od *OrderBook) breaksCircuit(price decimal.Decimal) bool {
+	if !od.circuitBreaker.Enabled() {
+		return false
+	}
+
+	reference := od.circuitReferencePrice()
+	if !reference.IsPositive() {
+		return false
+	}
+
+	deviation := price.Sub(reference).Abs().Mul(decimal.New(100, 0)).Div(reference)
+
+	return deviation.GreaterThan(od.circuitBreaker.Band)
+}
+
+// circuitReferencePrice returns the reference price of the price band.
+func (od *OrderBook) circuitReferencePrice() decimal.Decimal {
+	if od.circuitBreaker.Window <= 0 {
+		if od.staticReferencePrice.IsPositive() {
+			return od.staticReferencePrice
+		}
+
+		return od.Price
+	}
+
+	since := od.clock.Now().Add(-od.circuitBreaker.Window)
+	for len(od.referencePrices) > 0 && od.referencePrices[0].createdAt.Before(since) {
+		od.referencePrices = od.referencePrices[1:]
+	}
+
+	if len(od.referencePrices) == 0 {
+		return od.Price
+	}
+
+	return od.referencePrices[0].price
+}
+
+// recordReferencePrice records the trade price for the price band.
+func (od *OrderBook) recordReferencePrice(price decimal.Decimal) {
+	if !od.circuitBreaker.Enabled() {
+		return
+	}
+
+	if !od.staticReferencePrice.IsPositive() {
+		od.staticReferencePrice = price
+	}
+
+	if od.circuitBreaker.Window > 0 {
+		od.referencePrices = append(od.referencePrices, referencePrice{
+			price:     price,
+			createdAt: od.clock.Now(),
+		})
+	}
+}
+
+// resetReferencePrice restarts the price band from the market price when
+// continuous trading resumes.
+func (od *OrderBook) resetReferencePrice() {
+	od.staticReferencePrice = od.Price
+	od.referencePrices = nil
+}
+
+// tripCircuitBreaker halts continuous trading, or starts an auction to
+// re-open the orderbook.
+func (od *OrderBook) tripCircuitBreaker(price decimal.Decimal) {
+	state := TradingStateHalted
+	if od.circuitBreaker.ReopenByAuction {
+		state = TradingStatePreOpen
+	}
+
+	log.Warnf("[oceanbook.orderbook] circuit breaker of orderbook %s tripped by price %s, transits to %s", od.Symbol, price, state)
+
+	od.state = state
+}
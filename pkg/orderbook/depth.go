@@ -39,14 +39,41 @@ func (pl *PriceLevel) Key() *PriceLevelKey {
 	}
 }
 
-// Depth .
+// Depth is the aggregated quantity and orders count of price levels, prices
+// are grouped by Scale decimal places.
 type Depth struct {
 	Symbol string
 	Scale  int64
 	Bids   *rbt.Tree
 	Asks   *rbt.Tree
+
+	// Sequence is the sequence number of the last update of depth.
+	Sequence uint64
+
+	listener DepthListener
+}
+
+// DepthUpdate is the change of a price level in depth, it carries the
+// quantity and orders count of the price level after the change, and the
+// price level is removed when its orders count is zero.
+type DepthUpdate struct {
+	Sequence   uint64
+	PriceLevel PriceLevel
 }
 
+// Serialize returns a protobuf encoded depth update.
+func (u *DepthUpdate) Serialize() *oceanbookpb.DepthUpdate {
+	return &oceanbookpb.DepthUpdate{
+		Sequence:   u.Sequence,
+		Side:       u.PriceLevel.Side.Serialize(),
+		PriceLevel: u.PriceLevel.Serialize(),
+	}
+}
+
+// DepthListener receives depth updates in sequence order, and it is called
+// while the orderbook is locked.
+type DepthListener func(update *DepthUpdate)
+
 // NewDepth returns a depth with specific scale.
 func NewDepth(symbol string, scale int64) *Depth {
 	return &Depth{
@@ -72,17 +99,41 @@ func (d *Depth) Serialize() *oceanbookpb.Depth {
 	}
 
 	return &oceanbookpb.Depth{
-		Symbol: d.Symbol,
-		Bids:   bids,
-		Asks:   asks,
+		Symbol:   d.Symbol,
+		Bids:     bids,
+		Asks:     asks,
+		Sequence: d.Sequence,
 	}
 }
 
-// UpdatePriceLevel updates depth with price level.
+// clone returns a copy of depth without its listener.
+func (d *Depth) clone() *Depth {
+	depth := NewDepth(d.Symbol, d.Scale)
+	depth.Sequence = d.Sequence
+
+	for _, trees := range [][2]*rbt.Tree{{d.Bids, depth.Bids}, {d.Asks, depth.Asks}} {
+		it := trees[0].Iterator()
+		for it.Next() {
+			priceLevel := *it.Value().(*PriceLevel)
+			trees[1].Put(priceLevel.Key(), &priceLevel)
+		}
+	}
+
+	return depth
+}
+
+// UpdatePriceLevel adds the quantity and orders count of price level into
+// depth.
 func (d *Depth) UpdatePriceLevel(pl *PriceLevel) {
+	d.update(pl.Side, pl.Price, pl.Quantity, int64(pl.Count))
+}
+
+// update adds the quantity and orders count into the price level, and removes
+// the price level without orders.
+func (d *Depth) update(side order.Side, price, quantity decimal.Decimal, count int64) {
 	var priceLevels *rbt.Tree
 
-	switch pl.Side {
+	switch side {
 	case order.SideAsk:
 		priceLevels = d.Asks
 
@@ -90,22 +141,179 @@ func (d *Depth) UpdatePriceLevel(pl *PriceLevel) {
 		priceLevels = d.Bids
 
 	default:
-		log.Fatalf("[depth] invalid price level side %s", pl.Side)
+		log.Fatalf("[depth] invalid price level side %s", side)
 	}
 
-	foundPriceLevel, found := priceLevels.Get(pl.Key())
-	if !found {
-		priceLevels.Put(pl.Key(), pl)
+	key := &PriceLevelKey{
+		Price: price,
+		Side:  side,
+	}
+
+	foundPriceLevel, found := priceLevels.Get(key)
+	if !found && count <= 0 {
+		log.Warnf("[depth] %s price level %s not found", side, price)
+		return
+	}
+
+	if quantity.IsZero() && count == 0 {
 		return
 	}
 
-	existedPriceLevel := foundPriceLevel.(*PriceLevel)
-	existedPriceLevel.Quantity = existedPriceLevel.Quantity.Add(pl.Quantity)
-	existedPriceLevel.Count += pl.Count
+	priceLevel := &PriceLevel{
+		Price:    price,
+		Quantity: decimal.Zero,
+		Side:     side,
+	}
+	if found {
+		priceLevel = foundPriceLevel.(*PriceLevel)
+	} else {
+		priceLevels.Put(key, priceLevel)
+	}
+
+	priceLevel.Quantity = priceLevel.Quantity.Add(quantity)
+	priceLevel.Count = uint64(int64(priceLevel.Count) + count)
+
+	if priceLevel.Count == 0 {
+		priceLevels.Remove(key)
+	}
+
+	d.Sequence++
+	if d.listener != nil {
+		d.listener(&DepthUpdate{
+			Sequence:   d.Sequence,
+			PriceLevel: *priceLevel,
+		})
+	}
+}
+
+// Aggregate returns a copy of depth whose prices are grouped by the increment
+// and limited to the best price levels of each side, bid prices round down and
+// ask prices round up to the increment. Prices are grouped by the scale of depth
+// when increment is not positive, and all price levels are returned when limit
+// is not positive.
+func (d *Depth) Aggregate(increment decimal.Decimal, limit int) *Depth {
+	if !increment.IsPositive() {
+		increment = decimal.New(1, int32(-d.Scale))
+	}
+
+	depth := NewDepth(d.Symbol, decimalPlaces(increment))
+	for _, priceLevels := range []*rbt.Tree{d.Bids, d.Asks} {
+		aggregated := depth.Bids
+		if priceLevels == d.Asks {
+			aggregated = depth.Asks
+		}
 
-	if existedPriceLevel.Count == 0 || existedPriceLevel.Quantity.Equal(decimal.Zero) {
-		priceLevels.Remove(existedPriceLevel.Key())
+		it := priceLevels.Iterator()
+		for it.End(); it.Prev(); {
+			pl := it.Value().(*PriceLevel)
+			key := &PriceLevelKey{
+				Price: groupPrice(pl.Side, pl.Price, increment),
+				Side:  pl.Side,
+			}
+
+			// price levels are visited from the best one, the aggregated depth
+			// is complete once a new price level exceeds the limit.
+			if _, found := aggregated.Get(key); !found && limit > 0 && aggregated.Size() >= limit {
+				break
+			}
+
+			depth.update(key.Side, key.Price, pl.Quantity, int64(pl.Count))
+		}
+	}
+
+	depth.Sequence = d.Sequence
+
+	return depth
+}
+
+// groupPrice rounds the price to a multiple of increment, bid prices round
+// down and ask prices round up.
+func groupPrice(side order.Side, price, increment decimal.Decimal) decimal.Decimal {
+	remainder := price.Mod(increment)
+	if remainder.IsZero() {
+		return price
+	}
+
+	grouped := price.Sub(remainder)
+	if side == order.SideAsk {
+		grouped = grouped.Add(increment)
+	}
+
+	return grouped
+}
+
+// decimalPlaces returns the number of decimal places of the positive value, it
+// is negative for multiples of ten, such as -1 for 10.
+func decimalPlaces(value decimal.Decimal) int64 {
+	isInteger := func(places int32) bool {
+		shifted := value.Shift(places)
+		return shifted.Equal(shifted.Truncate(0))
+	}
+
+	places := int32(0)
+	for !isInteger(places) {
+		places++
+	}
+	for places > -int32(decimal.DivisionPrecision) && isInteger(places-1) {
+		places--
 	}
+
+	return int64(places)
+}
+
+// DepthDiff is the price level which differs between the maintained depth and
+// the depth rebuilt from orders, nil price level means it does not exist.
+type DepthDiff struct {
+	Expected *PriceLevel
+	Actual   *PriceLevel
+}
+
+// buildDepth rebuilds depth from the visible quantity of resting orders.
+func buildDepth(symbol string, scale int64, books ...*rbt.Tree) *Depth {
+	depth := NewDepth(symbol, scale)
+	for _, orders := range books {
+		it := orders.Iterator()
+		for it.Next() {
+			o := it.Value().(*order.Order)
+			depth.update(o.Side, o.Price, o.VisibleQuantity(), 1)
+		}
+	}
+
+	return depth
+}
+
+// diffDepth returns price levels of actual depth which differ from expected
+// depth.
+func diffDepth(expected, actual *Depth) []DepthDiff {
+	diffs := []DepthDiff{}
+	for _, trees := range [][2]*rbt.Tree{{expected.Bids, actual.Bids}, {expected.Asks, actual.Asks}} {
+		expectedLevels, actualLevels := trees[0], trees[1]
+
+		it := expectedLevels.Iterator()
+		for it.Next() {
+			expectedLevel := it.Value().(*PriceLevel)
+
+			found, ok := actualLevels.Get(it.Key())
+			if !ok {
+				diffs = append(diffs, DepthDiff{Expected: expectedLevel})
+				continue
+			}
+
+			actualLevel := found.(*PriceLevel)
+			if !actualLevel.Quantity.Equal(expectedLevel.Quantity) || actualLevel.Count != expectedLevel.Count {
+				diffs = append(diffs, DepthDiff{Expected: expectedLevel, Actual: actualLevel})
+			}
+		}
+
+		it = actualLevels.Iterator()
+		for it.Next() {
+			if _, ok := expectedLevels.Get(it.Key()); !ok {
+				diffs = append(diffs, DepthDiff{Actual: it.Value().(*PriceLevel)})
+			}
+		}
+	}
+
+	return diffs
 }
 
 // PriceLevelComparator .
@@ -3,6 +3,8 @@ package orderbook
 import (
 	"testing"
 
+	"github.com/draveness/oceanbook/pkg/order"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -12,3 +14,124 @@ func TestNewDepth(t *testing.T) {
 	assert.Equal(t, int64(1), depth.Scale)
 	assert.Equal(t, "BTC/CNY", depth.Symbol)
 }
+
+func TestDepthUpdate(t *testing.T) {
+	depth := NewDepth("BTC/CNY", 1)
+
+	depth.update(order.SideBid, decimal.NewFromFloat(10.0), decimal.NewFromFloat(1.0), 1)
+	depth.update(order.SideBid, decimal.NewFromFloat(10.0), decimal.NewFromFloat(2.0), 1)
+	depth.update(order.SideBid, decimal.NewFromFloat(10.0), decimal.NewFromFloat(-0.5), 0)
+
+	found, ok := depth.Bids.Get(&PriceLevelKey{Price: decimal.NewFromFloat(10.0), Side: order.SideBid})
+	assert.True(t, ok)
+	assert.True(t, decimal.NewFromFloat(2.5).Equal(found.(*PriceLevel).Quantity))
+	assert.Equal(t, uint64(2), found.(*PriceLevel).Count)
+
+	depth.update(order.SideBid, decimal.NewFromFloat(10.0), decimal.NewFromFloat(-0.5), -1)
+	depth.update(order.SideBid, decimal.NewFromFloat(10.0), decimal.NewFromFloat(-2.0), -1)
+	assert.Equal(t, 0, depth.Bids.Size())
+
+	depth.update(order.SideAsk, decimal.NewFromFloat(10.0), decimal.NewFromFloat(-1.0), -1)
+	assert.Equal(t, 0, depth.Asks.Size())
+}
+
+func TestDiffDepth(t *testing.T) {
+	expected := NewDepth("BTC/CNY", 1)
+	expected.update(order.SideBid, decimal.NewFromFloat(9.0), decimal.NewFromFloat(1.0), 1)
+	expected.update(order.SideAsk, decimal.NewFromFloat(11.0), decimal.NewFromFloat(1.0), 1)
+
+	actual := NewDepth("BTC/CNY", 1)
+	actual.update(order.SideBid, decimal.NewFromFloat(9.0), decimal.NewFromFloat(1.0), 1)
+	assert.Empty(t, diffDepth(actual, actual))
+
+	actual.update(order.SideAsk, decimal.NewFromFloat(12.0), decimal.NewFromFloat(1.0), 1)
+	actual.update(order.SideBid, decimal.NewFromFloat(9.0), decimal.NewFromFloat(1.0), 0)
+
+	diffs := diffDepth(expected, actual)
+	assert.Len(t, diffs, 3)
+	assert.True(t, decimal.NewFromFloat(2.0).Equal(diffs[0].Actual.Quantity))
+	assert.Nil(t, diffs[1].Actual)
+	assert.Nil(t, diffs[2].Expected)
+}
+
+func TestDepthAggregate(t *testing.T) {
+	depth := NewDepth("BTC/CNY", 16)
+	depth.update(order.SideBid, decimal.RequireFromString("10.05"), decimal.NewFromFloat(1.0), 1)
+	depth.update(order.SideBid, decimal.RequireFromString("10.2"), decimal.NewFromFloat(2.0), 2)
+	depth.update(order.SideBid, decimal.RequireFromString("9.5"), decimal.NewFromFloat(3.0), 1)
+	depth.update(order.SideAsk, decimal.RequireFromString("10.95"), decimal.NewFromFloat(1.0), 1)
+	depth.update(order.SideAsk, decimal.RequireFromString("11"), decimal.NewFromFloat(2.0), 1)
+	depth.update(order.SideAsk, decimal.RequireFromString("11.3"), decimal.NewFromFloat(3.0), 1)
+
+	aggregated := depth.Aggregate(decimal.Zero, 0)
+	assert.Equal(t, int64(16), aggregated.Scale)
+	assert.Equal(t, 3, aggregated.Bids.Size())
+	assert.Equal(t, 3, aggregated.Asks.Size())
+	assert.Empty(t, diffDepth(depth, aggregated))
+
+	aggregated = depth.Aggregate(decimal.RequireFromString("1"), 0)
+	assert.Equal(t, int64(0), aggregated.Scale)
+
+	bids := aggregated.Serialize().Bids
+	assert.Len(t, bids, 2)
+	assert.Equal(t, "9", bids[0].Price)
+	assert.Equal(t, "3", bids[0].Quantity)
+	assert.Equal(t, "10", bids[1].Price)
+	assert.Equal(t, "3", bids[1].Quantity)
+	assert.Equal(t, uint64(3), bids[1].OrdersCount)
+
+	asks := aggregated.Serialize().Asks
+	assert.Len(t, asks, 2)
+	assert.Equal(t, "12", asks[0].Price)
+	assert.Equal(t, "11", asks[1].Price)
+	assert.Equal(t, "3", asks[1].Quantity)
+	assert.Equal(t, uint64(2), asks[1].OrdersCount)
+
+	aggregated = depth.Aggregate(decimal.RequireFromString("0.1"), 2)
+	assert.Equal(t, int64(1), aggregated.Scale)
+
+	bids = aggregated.Serialize().Bids
+	assert.Len(t, bids, 2)
+	assert.Equal(t, "10", bids[0].Price)
+	assert.Equal(t, "10.2", bids[1].Price)
+
+	asks = aggregated.Serialize().Asks
+	assert.Len(t, asks, 2)
+	assert.Equal(t, "11.3", asks[0].Price)
+	assert.Equal(t, "11", asks[1].Price)
+	assert.Equal(t, "3", asks[1].Quantity)
+
+	aggregated = depth.Aggregate(decimal.RequireFromString("10"), 1)
+	assert.Equal(t, int64(-1), aggregated.Scale)
+	assert.Equal(t, "10", aggregated.Serialize().Bids[0].Price)
+	assert.Equal(t, "3", aggregated.Serialize().Bids[0].Quantity)
+	assert.Equal(t, "20", aggregated.Serialize().Asks[0].Price)
+}
+
+func TestDepthListener(t *testing.T) {
+	updates := []*DepthUpdate{}
+
+	depth := NewDepth("BTC/CNY", 16)
+	depth.listener = func(update *DepthUpdate) {
+		updates = append(updates, update)
+	}
+
+	depth.update(order.SideBid, decimal.NewFromFloat(10.0), decimal.NewFromFloat(1.0), 1)
+	snapshot := depth.clone()
+
+	depth.update(order.SideBid, decimal.NewFromFloat(10.0), decimal.Zero, 0)
+	depth.update(order.SideBid, decimal.NewFromFloat(10.0), decimal.NewFromFloat(2.0), 1)
+	depth.update(order.SideBid, decimal.NewFromFloat(10.0), decimal.NewFromFloat(-3.0), -2)
+
+	assert.Equal(t, uint64(3), depth.Sequence)
+	assert.Len(t, updates, 3)
+	assert.Equal(t, uint64(2), updates[1].Sequence)
+	assert.True(t, decimal.NewFromFloat(3.0).Equal(updates[1].PriceLevel.Quantity))
+	assert.Equal(t, uint64(2), updates[1].PriceLevel.Count)
+	assert.Equal(t, uint64(0), updates[2].PriceLevel.Count)
+	assert.Equal(t, 0, depth.Bids.Size())
+
+	assert.Equal(t, uint64(1), snapshot.Sequence)
+	assert.Equal(t, 1, snapshot.Bids.Size())
+	assert.Nil(t, snapshot.listener)
+}
@@ -0,0 +1,166 @@
+package orderbook
+
+import (
+	"github.com/draveness/oceanbook/pkg/order"
+	rbt "github.com/emirpasic/gods/trees/redblacktree"
+	"github.com/shopspring/decimal"
+)
+
+// Matcher is the matching algorithm which allocates taker quantity among
+// makers at the best price level.
+type Matcher interface {
+	// Allocate returns the quantity filled by each maker, makers are sorted by
+	// time priority and allocated no more than their visible quantity.
+	Allocate(makers []*order.Order, quantity, lotSize decimal.Decimal) []decimal.Decimal
+
+	// TimePriority returns true when makers are allocated by time priority
+	// only, makers behind the ones covering taker quantity are not collected.
+	TimePriority() bool
+}
+
+// FIFOMatcher allocates quantity by price-time priority.
+type FIFOMatcher struct{}
+
+// Allocate implements Matcher.
+func (FIFOMatcher) Allocate(makers []*order.Order, quantity, lotSize decimal.Decimal) []decimal.Decimal {
+	allocations := make([]decimal.Decimal, len(makers))
+	allocateFIFO(makers, allocations, quantity)
+
+	return allocations
+}
+
+// TimePriority implements Matcher.
+func (FIFOMatcher) TimePriority() bool {
+	return true
+}
+
+// ProRataMatcher allocates quantity in proportion to the visible quantity of
+// makers, and the quantity left by rounding down to lot size is allocated by
+// time priority.
+type ProRataMatcher struct{}
+
+// Allocate implements Matcher.
+func (ProRataMatcher) Allocate(makers []*order.Order, quantity, lotSize decimal.Decimal) []decimal.Decimal {
+	allocations := make([]decimal.Decimal, len(makers))
+	allocateProRata(makers, allocations, quantity, lotSize)
+
+	return allocations
+}
+
+// TimePriority implements Matcher.
+func (ProRataMatcher) TimePriority() bool {
+	return false
+}
+
+// ProRataTopOrderMatcher allocates quantity to the oldest maker at the price
+// level first, no more than TopOrderCap when it is positive, and allocates the
+// rest in proportion to the visible quantity of makers.
+type ProRataTopOrderMatcher struct {
+	TopOrderCap decimal.Decimal
+}
+
+// Allocate implements Matcher.
+func (m ProRataTopOrderMatcher) Allocate(makers []*order.Order, quantity, lotSize decimal.Decimal) []decimal.Decimal {
+	allocations := make([]decimal.Decimal, len(makers))
+	if len(makers) == 0 {
+		return allocations
+	}
+
+	top := decimal.Min(makers[0].VisibleQuantity(), quantity)
+	if m.TopOrderCap.IsPositive() {
+		top = decimal.Min(top, m.TopOrderCap)
+	}
+	allocations[0] = top
+
+	allocateProRata(makers, allocations, quantity.Sub(top), lotSize)
+
+	return allocations
+}
+
+// TimePriority implements Matcher.
+func (ProRataTopOrderMatcher) TimePriority() bool {
+	return false
+}
+
+// allocateFIFO adds quantity to allocations by time priority, and returns the
+// quantity which makers are not able to fill.
+func allocateFIFO(makers []*order.Order, allocations []decimal.Decimal, quantity decimal.Decimal) decimal.Decimal {
+	for i, maker := range makers {
+		if !quantity.IsPositive() {
+			break
+		}
+
+		allocation := decimal.Min(maker.VisibleQuantity().Sub(allocations[i]), quantity)
+		if !allocation.IsPositive() {
+			continue
+		}
+
+		allocations[i] = allocations[i].Add(allocation)
+		quantity = quantity.Sub(allocation)
+	}
+
+	return quantity
+}
+
+// allocateProRata adds quantity to allocations in proportion to the remaining
+// visible quantity of makers.
+func allocateProRata(makers []*order.Order, allocations []decimal.Decimal, quantity, lotSize decimal.Decimal) {
+	if !quantity.IsPositive() {
+		return
+	}
+
+	capacities := make([]decimal.Decimal, len(makers))
+	total := decimal.Zero
+	for i, maker := range makers {
+		capacities[i] = maker.VisibleQuantity().Sub(allocations[i])
+		total = total.Add(capacities[i])
+	}
+
+	// makers fill all their quantity when the taker is large enough.
+	if total.LessThanOrEqual(quantity) {
+		for i := range makers {
+			allocations[i] = allocations[i].Add(capacities[i])
+		}
+
+		return
+	}
+
+	allocated := decimal.Zero
+	for i := range makers {
+		share := quantity.Mul(capacities[i]).Div(total)
+		if lotSize.IsPositive() {
+			share = share.Div(lotSize).Floor().Mul(lotSize)
+		}
+		share = decimal.Min(share, quantity.Sub(allocated))
+
+		allocations[i] = allocations[i].Add(share)
+		allocated = allocated.Add(share)
+	}
+
+	allocateFIFO(makers, allocations, quantity.Sub(allocated))
+}
+
+// priceLevelOrders returns orders at the best price level by time priority,
+// and stops collecting when quantity is positive and covered by the collected
+// orders.
+func priceLevelOrders(books *rbt.Tree, quantity decimal.Decimal) []*order.Order {
+	orders := []*order.Order{}
+	visibleQuantity := decimal.Zero
+
+	it := books.Iterator()
+	for it.End(); it.Prev(); {
+		o := it.Value().(*order.Order)
+		if len(orders) > 0 && !o.Price.Equal(orders[0].Price) {
+			break
+		}
+
+		if quantity.IsPositive() && visibleQuantity.GreaterThanOrEqual(quantity) {
+			break
+		}
+
+		orders = append(orders, o)
+		visibleQuantity = visibleQuantity.Add(o.VisibleQuantity())
+	}
+
+	return orders
+}
@@ -0,0 +1,43 @@
+package orderbook
+
+import (
+	"testing"
+
+	"github.com/draveness/oceanbook/pkg/order"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatcherAllocate(t *testing.T) {
+	makers := []*order.Order{}
+	for id, quantity := range []float64{1.0, 3.0, 6.0} {
+		makers = append(makers, &order.Order{
+			ID:       uint64(id + 1),
+			Side:     order.SideAsk,
+			Price:    decimal.NewFromFloat(1.0),
+			Quantity: decimal.NewFromFloat(quantity),
+		})
+	}
+
+	tests := []struct {
+		name        string
+		matcher     Matcher
+		quantity    float64
+		allocations []float64
+	}{
+		{"fifo", FIFOMatcher{}, 5.0, []float64{1.0, 3.0, 1.0}},
+		{"pro rata", ProRataMatcher{}, 5.0, []float64{1.0, 1.0, 3.0}},
+		{"pro rata with large taker", ProRataMatcher{}, 12.0, []float64{1.0, 3.0, 6.0}},
+		{"pro rata top order", ProRataTopOrderMatcher{}, 5.0, []float64{1.0, 2.0, 2.0}},
+		{"pro rata top order with cap", ProRataTopOrderMatcher{TopOrderCap: decimal.NewFromFloat(0.5)}, 5.0, []float64{1.0, 2.0, 2.0}},
+	}
+
+	for _, test := range tests {
+		allocations := test.matcher.Allocate(makers, decimal.NewFromFloat(test.quantity), decimal.NewFromFloat(1.0))
+
+		assert.Len(t, allocations, len(test.allocations), test.name)
+		for i, allocation := range test.allocations {
+			assert.True(t, decimal.NewFromFloat(allocation).Equal(allocations[i]), "%s: maker %d allocated %s", test.name, i, allocations[i])
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package orderbook
+
+import (
+	"github.com/draveness/oceanbook/pkg/clock"
+	"github.com/shopspring/decimal"
+)
+
+// Option configures an orderbook.
+type Option func(*OrderBook)
+
+// WithMarketSpec sets the trading rules of the orderbook.
+func WithMarketSpec(spec MarketSpec) Option {
+	return func(od *OrderBook) {
+		od.Spec = spec
+	}
+}
+
+// WithTickSize sets the minimum price movement of the orderbook.
+func WithTickSize(tickSize decimal.Decimal) Option {
+	return func(od *OrderBook) {
+		od.Spec.TickSize = tickSize
+	}
+}
+
+// WithLotSize sets the minimum quantity movement of the orderbook.
+func WithLotSize(lotSize decimal.Decimal) Option {
+	return func(od *OrderBook) {
+		od.Spec.LotSize = lotSize
+	}
+}
+
+// WithClock sets the clock used by the orderbook.
+func WithClock(c clock.Clock) Option {
+	return func(od *OrderBook) {
+		od.clock = c
+	}
+}
+
+// WithMarketProtection bounds the prices market orders trade at.
+func WithMarketProtection(protection MarketProtection) Option {
+	return func(od *OrderBook) {
+		od.protection = protection
+	}
+}
+
+// WithMatcher sets the matching algorithm of the orderbook.
+func WithMatcher(matcher Matcher) Option {
+	return func(od *OrderBook) {
+		od.matcher = matcher
+	}
+}
+
+// WithTradingState sets the initial trading state of the orderbook.
+func WithTradingState(state TradingState) Option {
+	return func(od *OrderBook) {
+		od.state = state
+	}
+}
+
+// WithCircuitBreaker halts continuous trading when trade price moves out of
+// the price band.
+func WithCircuitBreaker(circuitBreaker CircuitBreaker) Option {
+	return func(od *OrderBook) {
+		od.circuitBreaker = circuitBreaker
+	}
+}
+
+// WithReporter sets the receiver of execution reports.
+func WithReporter(reporter Reporter) Option {
+	return func(od *OrderBook) {
+		od.reporter = reporter
+	}
+}
+
+// WithDepthListener sets the receiver of depth updates.
+func WithDepthListener(listener DepthListener) Option {
+	return func(od *OrderBook) {
+		od.depth.listener = listener
+	}
+}
+
+// WithOrderListener sets the receiver of order events of the order-by-order
+// feed.
+func WithOrderListener(listener OrderListener) Option {
+	return func(od *OrderBook) {
+		od.orderListener = listener
+	}
+}
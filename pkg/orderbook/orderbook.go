@@ -1,8 +1,11 @@
 package orderbook
 
 import (
+	"errors"
+	"sort"
 	"sync"
 
+	"github.com/draveness/oceanbook/pkg/clock"
 	// log level and settings
 	_ "github.com/draveness/oceanbook/pkg/log"
 	"github.com/draveness/oceanbook/pkg/order"
@@ -13,6 +16,51 @@ import (
 	log "github.com/sirupsen/logrus"
 )
 
+var (
+	// ErrPostOnlyOrderWouldCross returns when post only order would take liquidity.
+	ErrPostOnlyOrderWouldCross = errors.New("post only order would cross")
+
+	// ErrInvalidPostOnlyOrder returns when post only order is not a limit order.
+	ErrInvalidPostOnlyOrder = errors.New("invalid post only order")
+
+	// ErrOrderNotFound returns when order not found in the orderbook.
+	ErrOrderNotFound = errors.New("order not found")
+
+	// ErrInvalidAmendQuantity returns when amended quantity is not greater than filled quantity.
+	ErrInvalidAmendQuantity = errors.New("invalid amend quantity")
+
+	// ErrOrderExpired returns when order expires before inserted.
+	ErrOrderExpired = errors.New("order expired")
+
+	// ErrInvalidStopOrder returns when stop order has invalid stop price,
+	// trigger or stop type.
+	ErrInvalidStopOrder = errors.New("invalid stop order")
+
+	// ErrStopOrderWouldTrigger returns when market price already reaches the
+	// stop price of new stop order.
+	ErrStopOrderWouldTrigger = errors.New("stop order would trigger immediately")
+
+	// ErrInvalidTrailingStopOrder returns when trailing stop order has both
+	// trailing amount and percentage, or no stop price can be derived.
+	ErrInvalidTrailingStopOrder = errors.New("invalid trailing stop order")
+
+	// ErrInvalidQuoteQuantityOrder returns when quote quantity is used by
+	// orders other than bid market orders without base quantity.
+	ErrInvalidQuoteQuantityOrder = errors.New("invalid quote quantity order")
+
+	// ErrMarketProtectionCancelled returns when the remainder of market order
+	// is cancelled by market protection.
+	ErrMarketProtectionCancelled = errors.New("market order remainder cancelled by price protection")
+
+	// ErrDuplicateOrder returns when an order with the same id is already in
+	// the orderbook.
+	ErrDuplicateOrder = errors.New("duplicate order")
+
+	// ErrInvalidOCOOrder returns when linked orders are not able to rest in
+	// the orderbook, or share the same id or group id with existing orders.
+	ErrInvalidOCOOrder = errors.New("invalid one-cancels-other order")
+)
+
 // OrderBook is the order book.
 type OrderBook struct {
 	sync.RWMutex
@@ -27,7 +75,50 @@ type OrderBook struct {
 	pendingOrdersQueue *queue.OrderQueue
 	cancelOrdersQueue  map[uint64]*order.Order
 
+	// ocoGroups are linked orders indexed by group id, a fill or trigger of
+	// one order cancels the others in the group.
+	ocoGroups map[uint64][]*order.Order
+
+	// expiries indexes resting and stop orders by expiry time.
+	expiries *rbt.Tree
+
+	// stopOrders indexes orders in stop books by id.
+	stopOrders map[uint64]*order.Order
+
+	// pendingOrders indexes triggered stop orders in pending orders queue by
+	// id, they wait there while continuous trading is suspended.
+	pendingOrders map[uint64]*order.Order
+
+	// trailingOrders are stop orders whose stop prices follow market price.
+	trailingOrders map[uint64]*order.Order
+
 	depth *Depth
+	clock clock.Clock
+
+	// Spec is the trading rules of the market.
+	Spec MarketSpec
+
+	protection MarketProtection
+	matcher    Matcher
+
+	state TradingState
+
+	circuitBreaker       CircuitBreaker
+	staticReferencePrice decimal.Decimal
+	referencePrices      []referencePrice
+
+	reporter Reporter
+
+	// lastTradeID is the id of the latest trade, trades are numbered
+	// consecutively so that gaps in trades are detected.
+	lastTradeID uint64
+
+	// publicIDs maps ids of resting orders to the anonymised ids in order
+	// events.
+	publicIDs     map[uint64]uint64
+	lastPublicID  uint64
+	orderSequence uint64
+	orderListener OrderListener
 }
 
 const (
@@ -36,9 +127,9 @@ const (
 )
 
 // NewOrderBook returns a pointer to an orderbook.
-func NewOrderBook(symbol string) *OrderBook {
+func NewOrderBook(symbol string, options ...Option) *OrderBook {
 	orderQueue := queue.NewOrderQueue(pendingOrdersCap)
-	return &OrderBook{
+	od := &OrderBook{
 		Symbol:             symbol,
 		Bids:               rbt.NewWith(order.Comparator),
 		Asks:               rbt.NewWith(order.Comparator),
@@ -46,32 +137,181 @@ func NewOrderBook(symbol string) *OrderBook {
 		StopAsks:           rbt.NewWith(order.StopComparator),
 		pendingOrdersQueue: &orderQueue,
 		cancelOrdersQueue:  make(map[uint64]*order.Order, 1024),
+		ocoGroups:          make(map[uint64][]*order.Order),
+		expiries:           rbt.NewWith(order.ExpiryComparator),
+		stopOrders:         make(map[uint64]*order.Order),
+		pendingOrders:      make(map[uint64]*order.Order),
+		trailingOrders:     make(map[uint64]*order.Order),
+		publicIDs:          make(map[uint64]uint64),
 		depth:              NewDepth(symbol, 16),
+		clock:              clock.New(),
+		matcher:            FIFOMatcher{},
+		state:              TradingStateContinuous,
+	}
+
+	for _, option := range options {
+		option(od)
 	}
+
+	return od
 }
 
 // InsertOrder inserts new order into orderbook.
-func (od *OrderBook) InsertOrder(newOrder *order.Order) []*trade.Trade {
+func (od *OrderBook) InsertOrder(newOrder *order.Order) ([]*trade.Trade, error) {
 	od.Lock()
 	defer od.Unlock()
 
 	log.Debugf("[oceanbook.orderbook] insert order with id %d - %s * %s, side %s", newOrder.ID, newOrder.Price, newOrder.Quantity, newOrder.Side)
 
-	if !newOrder.StopPrice.Equal(decimal.Zero) {
-		od.insertStopOrder(newOrder)
+	od.expireOrders()
+
+	trades, err := od.insert(newOrder)
+
+	return append(trades, od.insertPendingOrders()...), err
+}
+
+// InsertOCOOrder inserts two linked orders into orderbook, a fill or trigger
+// of one order cancels the other one. Both orders have to be able to rest in
+// the orderbook, and the other order is never inserted when the first order
+// trades immediately.
+func (od *OrderBook) InsertOCOOrder(first, second *order.Order) ([]*trade.Trade, error) {
+	od.Lock()
+	defer od.Unlock()
+
+	log.Debugf("[oceanbook.orderbook] insert oco orders with id %d and %d", first.ID, second.ID)
 
-		return []*trade.Trade{}
+	od.expireOrders()
+
+	if first.ID == second.ID || !canRest(first) || !canRest(second) {
+		od.reject(first, ErrInvalidOCOOrder)
+		return []*trade.Trade{}, od.reject(second, ErrInvalidOCOOrder)
+	}
+
+	groupID := first.GroupID
+	if groupID == 0 {
+		groupID = first.ID
 	}
 
-	trades := od.insertOrder(newOrder)
+	if _, exists := od.ocoGroups[groupID]; exists {
+		od.reject(first, ErrInvalidOCOOrder)
+		return []*trade.Trade{}, od.reject(second, ErrInvalidOCOOrder)
+	}
+
+	first.GroupID = groupID
+	second.GroupID = groupID
+	od.ocoGroups[groupID] = []*order.Order{first, second}
 
-	pendingOrders := od.pendingOrdersQueue.Values()
-	for i := range pendingOrders {
-		pendingOrder := pendingOrders[i]
+	trades, err := od.insert(first)
+	if err != nil {
+		delete(od.ocoGroups, groupID)
+		return trades, od.reject(second, err)
+	}
+
+	// the first order traded and cancelled the second one.
+	if _, ok := od.ocoGroups[groupID]; !ok {
+		return append(trades, od.insertPendingOrders()...), nil
+	}
+
+	newTrades, err := od.insert(second)
+	trades = append(trades, newTrades...)
+	if err != nil {
+		od.removeOrder(first)
+		od.reportState(first, order.StateCancelled, err.Error())
+		delete(od.ocoGroups, groupID)
+		return trades, err
+	}
+
+	return append(trades, od.insertPendingOrders()...), nil
+}
+
+// canRest returns true when the order rests in the orderbook or stop books
+// instead of being dropped after matching.
+func canRest(o *order.Order) bool {
+	if o.ImmediateOrCancel || o.FillOrKill {
+		return false
+	}
+
+	return o.IsLimit() || o.IsStop() || o.IsTrailing()
+}
+
+// isQuoteQuantityOrder returns true when the order is a valid bid market order
+// sized by quote amount.
+func isQuoteQuantityOrder(o *order.Order) bool {
+	return o.Side == order.SideBid && o.IsMarket() && o.Quantity.IsZero() && !o.FillOrKill
+}
+
+// insert inserts new limit, market or stop order into orderbook.
+func (od *OrderBook) insert(newOrder *order.Order) ([]*trade.Trade, error) {
+	// orders are identified by id on both sides of orderbook.
+	if _, exists := od.openOrder(newOrder.ID); exists {
+		return []*trade.Trade{}, od.reject(newOrder, ErrDuplicateOrder)
+	}
+
+	if newOrder.Expired(od.clock.Now()) {
+		return []*trade.Trade{}, od.reject(newOrder, ErrOrderExpired)
+	}
+
+	if err := od.allowInsert(newOrder); err != nil {
+		return []*trade.Trade{}, od.reject(newOrder, err)
+	}
+
+	if newOrder.IsQuoteQuantity() && !isQuoteQuantityOrder(newOrder) {
+		return []*trade.Trade{}, od.reject(newOrder, ErrInvalidQuoteQuantityOrder)
+	}
+
+	if err := od.Spec.Validate(newOrder); err != nil {
+		return []*trade.Trade{}, od.reject(newOrder, err)
+	}
+
+	// time priority of orders follows the clock of orderbook, the same clock
+	// as amended and replenished orders, and duplicates are rejected by id
+	// above since the key of order changes with it.
+	newOrder.CreatedAt = od.clock.Now()
+
+	if newOrder.IsTrailing() {
+		if err := od.prepareTrailingStopOrder(newOrder); err != nil {
+			return []*trade.Trade{}, od.reject(newOrder, err)
+		}
+	}
+
+	if newOrder.IsStop() {
+		if err := od.insertStopOrder(newOrder); err != nil {
+			return []*trade.Trade{}, od.reject(newOrder, err)
+		}
+
+		return []*trade.Trade{}, nil
+	}
+
+	return od.insertOrder(newOrder)
+}
+
+// insertPendingOrders inserts triggered stop orders into orderbook.
+func (od *OrderBook) insertPendingOrders() []*trade.Trade {
+	trades := []*trade.Trade{}
+
+	// triggered orders may trade and trigger more stop orders.
+	for od.pendingOrdersQueue.Size() > 0 {
+		// triggered orders wait until continuous trading resumes.
+		if od.state != TradingStateContinuous {
+			return trades
+		}
+
+		pendingOrder := od.pendingOrdersQueue.Pop()
+		delete(od.pendingOrders, pendingOrder.ID)
+
+		// triggered orders may expire while trading is suspended.
+		if pendingOrder.Expired(od.clock.Now()) {
+			od.reportState(pendingOrder, order.StateExpired, "")
+			log.Infof("[oceanbook.orderbook] stop order %d expired at %s", pendingOrder.ID, pendingOrder.ExpiresAt)
+			continue
+		}
 
 		log.Debugf("[oceanbook.orderbook] insert stop order with id %d - %s * %s, side %s", pendingOrder.ID, pendingOrder.Price, pendingOrder.Quantity, pendingOrder.Side)
 
-		newTrades := od.insertOrder(pendingOrder)
+		newTrades, err := od.insertOrder(pendingOrder)
+		if err != nil {
+			log.Infof("[oceanbook.orderbook] stop order %d rejected, err: %s", pendingOrder.ID, err.Error())
+		}
 		trades = append(trades, newTrades...)
 	}
 	od.pendingOrdersQueue.Clear()
@@ -79,7 +319,7 @@ func (od *OrderBook) InsertOrder(newOrder *order.Order) []*trade.Trade {
 	return trades
 }
 
-func (od *OrderBook) insertOrder(newOrder *order.Order) []*trade.Trade {
+func (od *OrderBook) insertOrder(newOrder *order.Order) ([]*trade.Trade, error) {
 	trades := []*trade.Trade{}
 
 	var takerBooks, makerBooks *rbt.Tree
@@ -94,68 +334,481 @@ func (od *OrderBook) insertOrder(newOrder *order.Order) []*trade.Trade {
 
 	default:
 		log.Fatalf("[oceanbook.orderbook] invalid order side %s", newOrder.Side)
-		return trades
+		return trades, nil
 	}
 
-	_, found := takerBooks.Get(newOrder.Key())
-	if found {
-		return trades
+	// orders accumulate without matching during the auction.
+	if od.state == TradingStatePreOpen {
+		if newOrder.ImmediateOrCancel || newOrder.FillOrKill || newOrder.IsMarket() {
+			return trades, od.reject(newOrder, ErrInvalidAuctionOrder)
+		}
+
+		od.accept(newOrder)
+		od.restOrder(takerBooks, newOrder)
+
+		return trades, nil
 	}
 
-	for {
-		if newOrder == nil {
-			break
+	// protected market order only takes makers within the protection price.
+	var protectedOrder *order.Order
+	if newOrder.IsMarket() && !newOrder.PostOnly {
+		if price := od.protectionPrice(newOrder.Side, makerBooks); price.IsPositive() {
+			boundedOrder := *newOrder
+			boundedOrder.Price = price
+			protectedOrder = &boundedOrder
+		}
+	}
+
+	// fill or kill order is dropped before touching any maker when the
+	// opposite books are not able to fill it completely.
+	if newOrder.FillOrKill {
+		fillOrder := newOrder
+		if protectedOrder != nil {
+			fillOrder = protectedOrder
+		}
+
+		if !od.canFill(makerBooks, fillOrder) {
+			od.reportState(newOrder, order.StateCancelled, reasonNotFilled)
+			return trades, nil
+		}
+	}
+
+	if newOrder.PostOnly {
+		if err := od.preparePostOnlyOrder(newOrder, makerBooks); err != nil {
+			return trades, od.reject(newOrder, err)
 		}
+	}
+
+	od.accept(newOrder)
 
+	for {
 		best := makerBooks.Right()
 		if best == nil {
 			break
 		}
 
 		bestOrder := best.Value.(*order.Order)
-		newTrade := bestOrder.Match(newOrder)
 
-		if newTrade == nil {
+		if protectedOrder != nil && !bestOrder.Crosses(protectedOrder) {
+			return od.protectOrder(takerBooks, newOrder, protectedOrder.Price, trades)
+		}
+
+		// quote quantity order buys as much as its remaining quote amount
+		// affords at the price of each maker.
+		if newOrder.IsQuoteQuantity() {
+			quantity := newOrder.AffordableQuantity(bestOrder.Price, od.Spec.LotSize)
+			if !quantity.IsPositive() {
+				break
+			}
+
+			newOrder.Quantity = newOrder.FilledQuantity.Add(quantity)
+		}
+
+		if !bestOrder.Crosses(newOrder) {
 			break
 		}
 
-		trades = append(trades, newTrade)
-		log.Debugf("[oceanbook.orderbook] new trade %d with price %s", newTrade.ID, newTrade.Price)
+		// makers at the best price level share the taker by the matching
+		// algorithm, and are allocated again after self trade prevention.
+		limit := decimal.Zero
+		if od.matcher.TimePriority() {
+			limit = newOrder.PendingQuantity()
+		}
+		makers := priceLevelOrders(makerBooks, limit)
+		allocations := od.matcher.Allocate(makers, newOrder.PendingQuantity(), od.Spec.LotSize)
+
+		matched := false
+		for i, maker := range makers {
+			if !allocations[i].IsPositive() {
+				continue
+			}
+
+			// maker may be cancelled by linked orders filled before, and a
+			// maker left in books without being indexed is removed so that
+			// it is never matched again.
+			if indexedOrder, ok := od.cancelOrdersQueue[maker.ID]; !ok || indexedOrder != maker {
+				if _, found := makerBooks.Get(maker.Key()); found {
+					log.Warnf("[oceanbook.orderbook] remove stale order %d from orderbook %s", maker.ID, od.Symbol)
+
+					makerBooks.Remove(maker.Key())
+					od.depth.update(maker.Side, maker.Price, maker.VisibleQuantity().Neg(), -1)
+				}
+
+				matched = true
+				continue
+			}
+
+			if maker.IsSelfTrade(newOrder) {
+				if od.preventSelfTrade(maker, newOrder) {
+					od.unlinkOrder(newOrder)
+					od.reportState(newOrder, order.StateCancelled, reasonSelfTrade)
+					return trades, nil
+				}
+
+				matched = true
+				break
+			}
+
+			// trade outside the price band halts continuous trading
+			// before the maker is filled.
+			if od.breaksCircuit(maker.Price) {
+				od.tripCircuitBreaker(maker.Price)
+				od.reportState(newOrder, order.StateCancelled, ErrCircuitBreakerTripped.Error())
+				return trades, ErrCircuitBreakerTripped
+			}
+
+			visibleQuantity := maker.VisibleQuantity()
+			newTrade := maker.MatchQuantity(newOrder, allocations[i])
+			if newTrade == nil {
+				continue
+			}
+			matched = true
+			od.stampTrade(newTrade)
+
+			trades = append(trades, newTrade)
+			od.reportTrade(newTrade, maker, newOrder)
+			log.Debugf("[oceanbook.orderbook] new trade %d with price %s", newTrade.ID, newTrade.Price)
+
+			od.publishExecute(maker, newTrade.Quantity)
+
+			switch {
+			case maker.Filled():
+				makerBooks.Remove(maker.Key())
+				delete(od.cancelOrdersQueue, maker.ID)
+				od.expiries.Remove(maker.Key())
+				od.depth.update(maker.Side, maker.Price, visibleQuantity.Neg(), -1)
+
+			case maker.VisibleQuantity().IsZero():
+				od.replenishOrder(makerBooks, maker)
+				od.publishAdd(makerBooks, maker)
+				fallthrough
+
+			default:
+				od.depth.update(maker.Side, maker.Price, maker.VisibleQuantity().Sub(visibleQuantity), 0)
+			}
 
-		od.depth.UpdatePriceLevel(&PriceLevel{
-			Side:  bestOrder.Side,
-			Price: newTrade.Quantity.Neg(),
-		})
+			od.cancelLinkedOrders(maker)
+			od.cancelLinkedOrders(newOrder)
 
-		if bestOrder.Filled() {
-			makerBooks.Remove(bestOrder.Key())
-			delete(od.cancelOrdersQueue, bestOrder.ID)
+			od.setMarketPrice(newTrade.Price)
+
+			if newOrder.Filled() {
+				return trades, nil
+			}
 		}
 
-		od.setMarketPrice(newTrade.Price)
+		if !matched {
+			break
+		}
+	}
 
-		if newOrder.Filled() {
-			return trades
+	// if the order is immediate or cancel order or market order, it is not
+	// supposed to insert into the orderbooks.
+	if newOrder.ImmediateOrCancel || newOrder.FillOrKill || newOrder.IsMarket() {
+		od.reportState(newOrder, order.StateCancelled, reasonNotFilled)
+		return trades, nil
+	}
+
+	od.restOrder(takerBooks, newOrder)
+
+	return trades, nil
+}
+
+// restOrder puts the order into orderbook as a maker.
+func (od *OrderBook) restOrder(books *rbt.Tree, o *order.Order) {
+	o.Replenish()
+	od.depth.update(o.Side, o.Price, o.VisibleQuantity(), 1)
+	books.Put(o.Key(), o)
+	od.cancelOrdersQueue[o.ID] = o
+	od.indexExpiry(o)
+	od.publishAdd(books, o)
+}
+
+// protectOrder cancels the remainder of market order which reaches the
+// protection price, or rests it as a limit order at the protection price.
+func (od *OrderBook) protectOrder(takerBooks *rbt.Tree, newOrder *order.Order, price decimal.Decimal, trades []*trade.Trade) ([]*trade.Trade, error) {
+	if !od.protection.RestRemainder || newOrder.ImmediateOrCancel || newOrder.FillOrKill || newOrder.IsQuoteQuantity() {
+		log.Infof("[oceanbook.orderbook] market order %d cancelled at protection price %s", newOrder.ID, price)
+		od.reportState(newOrder, order.StateCancelled, ErrMarketProtectionCancelled.Error())
+		return trades, ErrMarketProtectionCancelled
+	}
+
+	log.Infof("[oceanbook.orderbook] market order %d rests at protection price %s", newOrder.ID, price)
+
+	newOrder.Price = price
+	od.restOrder(takerBooks, newOrder)
+	od.reportState(newOrder, newOrder.State, reasonProtectionRested)
+
+	return trades, nil
+}
+
+// preventSelfTrade cancels or decreases orders from the same owner by the
+// self trade prevention mode of taker, and returns true when the taker is
+// cancelled.
+func (od *OrderBook) preventSelfTrade(maker, taker *order.Order) bool {
+	log.Debugf("[oceanbook.orderbook] prevent self trade between %d and %d with mode %s", maker.ID, taker.ID, taker.SelfTradePrevention)
+
+	switch taker.SelfTradePrevention {
+	case order.SelfTradePreventionCancelOldest:
+		od.cancelSelfTradeOrder(maker)
+		return false
+
+	case order.SelfTradePreventionCancelBoth:
+		od.cancelSelfTradeOrder(maker)
+		return true
+
+	case order.SelfTradePreventionDecrementAndCancel:
+		quantity := decimal.Min(maker.PendingQuantity(), taker.PendingQuantity())
+		taker.Decrease(quantity)
+
+		od.decrementSelfTradeOrder(maker, quantity)
+
+		return taker.PendingQuantity().IsZero()
+
+	default:
+		return true
+	}
+}
+
+// cancelSelfTradeOrder cancels the resting order by self trade prevention
+// without cancelling its linked orders.
+func (od *OrderBook) cancelSelfTradeOrder(o *order.Order) {
+	od.removeOrder(o)
+	od.unlinkOrder(o)
+	od.reportState(o, order.StateCancelled, reasonSelfTrade)
+}
+
+// decrementSelfTradeOrder lowers the quantity of the resting order in place by
+// self trade prevention, the order keeps its time priority and it is cancelled
+// when no quantity is left.
+func (od *OrderBook) decrementSelfTradeOrder(o *order.Order, quantity decimal.Decimal) {
+	if o.PendingQuantity().Equal(quantity) {
+		od.removeOrder(o)
+		od.unlinkOrder(o)
+		o.Decrease(quantity)
+		od.reportState(o, order.StateCancelled, reasonSelfTrade)
+		return
+	}
+
+	visibleQuantity := o.VisibleQuantity()
+	o.Decrease(quantity)
+	od.depth.update(o.Side, o.Price, o.VisibleQuantity().Sub(visibleQuantity), 0)
+	od.publishModify(o, visibleQuantity)
+}
+
+// removeOrder removes the resting, stop or triggered but pending order from
+// orderbook, and returns false when the order is not in the orderbook.
+func (od *OrderBook) removeOrder(o *order.Order) bool {
+	var books, stopBooks *rbt.Tree
+	switch o.Side {
+	case order.SideAsk:
+		books = od.Asks
+		stopBooks = od.StopAsks
+
+	case order.SideBid:
+		books = od.Bids
+		stopBooks = od.StopBids
+	}
+
+	od.expiries.Remove(o.Key())
+
+	if _, found := od.pendingOrders[o.ID]; found {
+		od.pendingOrdersQueue.Remove(o.ID)
+		delete(od.pendingOrders, o.ID)
+		return true
+	}
+
+	if _, found := stopBooks.Get(o.Key()); found {
+		stopBooks.Remove(o.Key())
+		delete(od.stopOrders, o.ID)
+		delete(od.trailingOrders, o.ID)
+		return true
+	}
+
+	if _, found := books.Get(o.Key()); !found {
+		return false
+	}
+
+	books.Remove(o.Key())
+	delete(od.cancelOrdersQueue, o.ID)
+	od.depth.update(o.Side, o.Price, o.VisibleQuantity().Neg(), -1)
+	od.publishDelete(o)
+
+	return true
+}
+
+// cancelLinkedOrders cancels the other orders in the one-cancels-other group
+// of the order, and returns the cancelled orders.
+func (od *OrderBook) cancelLinkedOrders(o *order.Order) []*order.Order {
+	if o.GroupID == 0 {
+		return nil
+	}
+
+	linkedOrders, ok := od.ocoGroups[o.GroupID]
+	if !ok {
+		return nil
+	}
+	delete(od.ocoGroups, o.GroupID)
+
+	cancelledOrders := []*order.Order{}
+	for _, linkedOrder := range linkedOrders {
+		if linkedOrder.ID == o.ID {
+			continue
 		}
+
+		// linked orders which already left the orderbook keep their states.
+		if !od.removeOrder(linkedOrder) {
+			continue
+		}
+
+		od.reportState(linkedOrder, order.StateCancelled, reasonLinkedOrder)
+		cancelledOrders = append(cancelledOrders, linkedOrder)
+
+		log.Debugf("[oceanbook.orderbook] oco order %d cancelled by order %d", linkedOrder.ID, o.ID)
 	}
 
-	// if the order is immediate or cancel order, it is not supposed to insert
-	// into the orderbooks.
-	if newOrder.ImmediateOrCancel {
-		return trades
+	return cancelledOrders
+}
+
+// unlinkOrder removes the one-cancels-other group of the order which leaves
+// the orderbook without cancelling its linked orders, such as expired orders
+// and orders cancelled by self trade prevention. The linked orders stay in the
+// orderbook as standalone orders.
+func (od *OrderBook) unlinkOrder(o *order.Order) {
+	if o.GroupID == 0 {
+		return
 	}
 
-	od.depth.UpdatePriceLevel(&PriceLevel{
-		Side:  newOrder.Side,
-		Price: newOrder.PendingQuantity(),
-	})
-	takerBooks.Put(newOrder.Key(), newOrder)
-	od.cancelOrdersQueue[newOrder.ID] = newOrder
+	linkedOrders, ok := od.ocoGroups[o.GroupID]
+	if !ok {
+		return
+	}
+	delete(od.ocoGroups, o.GroupID)
 
-	return trades
+	for _, linkedOrder := range linkedOrders {
+		if linkedOrder.ID != o.ID {
+			linkedOrder.GroupID = 0
+		}
+	}
+}
+
+// replenishOrder refreshes the displayed slice of iceberg order, and the
+// refreshed order loses its time priority in the price level. Callers update
+// depth with the change of its visible quantity and publish it as a new order.
+func (od *OrderBook) replenishOrder(books *rbt.Tree, o *order.Order) {
+	books.Remove(o.Key())
+
+	o.Replenish()
+	o.CreatedAt = od.clock.Now()
+
+	books.Put(o.Key(), o)
+
+	log.Debugf("[oceanbook.orderbook] iceberg order %d replenished with %s", o.ID, o.VisibleQuantity())
+}
+
+// preparePostOnlyOrder rejects the post only order which would take liquidity
+// from makers, or slides its price one tick behind the best opposite price.
+func (od *OrderBook) preparePostOnlyOrder(newOrder *order.Order, makerBooks *rbt.Tree) error {
+	if !newOrder.IsLimit() {
+		return ErrInvalidPostOnlyOrder
+	}
+
+	best := makerBooks.Right()
+	if best == nil {
+		return nil
+	}
+
+	bestOrder := best.Value.(*order.Order)
+	if !bestOrder.Crosses(newOrder) {
+		return nil
+	}
+
+	if !newOrder.PostOnlySlide || !od.Spec.TickSize.IsPositive() {
+		return ErrPostOnlyOrderWouldCross
+	}
+
+	price := bestOrder.Price.Sub(od.Spec.TickSize)
+	if newOrder.Side == order.SideAsk {
+		price = bestOrder.Price.Add(od.Spec.TickSize)
+	}
+
+	if !price.IsPositive() {
+		return ErrPostOnlyOrderWouldCross
+	}
+
+	log.Debugf("[oceanbook.orderbook] post only order %d slides from %s to %s", newOrder.ID, newOrder.Price, price)
+
+	newOrder.Price = price
+
+	return nil
 }
 
-func (od *OrderBook) insertStopOrder(newOrder *order.Order) {
+// canFill returns true when makers with acceptable prices inside the price
+// band of circuit breaker have enough quantity to fill the taker.
+func (od *OrderBook) canFill(makerBooks *rbt.Tree, taker *order.Order) bool {
+	quantity := decimal.Zero
+
+	it := makerBooks.Iterator()
+	for it.End(); it.Prev(); {
+		maker := it.Value().(*order.Order)
+		if !maker.Crosses(taker) || od.breaksCircuit(maker.Price) {
+			return false
+		}
+
+		if maker.IsSelfTrade(taker) {
+			if taker.SelfTradePrevention == order.SelfTradePreventionCancelOldest {
+				continue
+			}
+
+			return false
+		}
+
+		quantity = quantity.Add(maker.PendingQuantity())
+		if quantity.GreaterThanOrEqual(taker.PendingQuantity()) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (od *OrderBook) insertStopOrder(newOrder *order.Order) error {
+	if !newOrder.StopPrice.IsPositive() {
+		return ErrInvalidStopOrder
+	}
+
+	switch newOrder.StopType {
+	case "":
+		newOrder.StopType = order.StopTypeLimit
+		if newOrder.IsMarket() {
+			newOrder.StopType = order.StopTypeMarket
+		}
+
+	case order.StopTypeLimit:
+		if !newOrder.IsLimit() {
+			return ErrInvalidStopOrder
+		}
+
+	case order.StopTypeMarket:
+		if !newOrder.IsMarket() {
+			return ErrInvalidStopOrder
+		}
+
+	default:
+		return ErrInvalidStopOrder
+	}
+
+	newOrder.Trigger = newOrder.TriggerDirection()
+	switch newOrder.Trigger {
+	case order.TriggerRisesTo, order.TriggerFallsTo:
+	default:
+		return ErrInvalidStopOrder
+	}
+
+	if od.Price.IsPositive() && newOrder.Triggered(od.Price) {
+		return ErrStopOrderWouldTrigger
+	}
+
 	var takerBooks *rbt.Tree
 	switch newOrder.Side {
 	case order.SideAsk:
@@ -166,96 +819,318 @@ func (od *OrderBook) insertStopOrder(newOrder *order.Order) {
 
 	default:
 		log.Fatalf("[oceanbook.orderbook] invalid stop order side %s", newOrder.Side)
-		return
+		return nil
+	}
+
+	od.accept(newOrder)
+	takerBooks.Put(newOrder.Key(), newOrder)
+	od.stopOrders[newOrder.ID] = newOrder
+	od.indexExpiry(newOrder)
+
+	if newOrder.IsTrailing() {
+		od.trailingOrders[newOrder.ID] = newOrder
 	}
 
-	_, found := takerBooks.Get(newOrder.Key())
-	if found {
+	return nil
+}
+
+// prepareTrailingStopOrder sets the initial stop price of trailing stop order
+// from market price when it is not specified.
+func (od *OrderBook) prepareTrailingStopOrder(newOrder *order.Order) error {
+	if newOrder.TrailingAmount.IsPositive() && newOrder.TrailingPercent.IsPositive() {
+		return ErrInvalidTrailingStopOrder
+	}
+
+	if newOrder.IsStop() {
+		return nil
+	}
+
+	if !od.Price.IsPositive() {
+		return ErrInvalidTrailingStopOrder
+	}
+
+	newOrder.StopPrice = newOrder.TrailingStopPrice(od.Price)
+	if !newOrder.StopPrice.IsPositive() {
+		return ErrInvalidTrailingStopOrder
+	}
+
+	return nil
+}
+
+// trailStopOrders moves stop prices of trailing stop orders when market price
+// goes in the favourable direction.
+func (od *OrderBook) trailStopOrders(price decimal.Decimal) {
+	for _, trailingOrder := range od.trailingOrders {
+		stopPrice := trailingOrder.TrailingStopPrice(price)
+
+		switch trailingOrder.TriggerDirection() {
+		case order.TriggerFallsTo:
+			if !stopPrice.GreaterThan(trailingOrder.StopPrice) {
+				continue
+			}
+
+		case order.TriggerRisesTo:
+			if !stopPrice.LessThan(trailingOrder.StopPrice) {
+				continue
+			}
+		}
+
+		stopBooks := od.StopBids
+		if trailingOrder.Side == order.SideAsk {
+			stopBooks = od.StopAsks
+		}
+
+		stopBooks.Remove(trailingOrder.Key())
+		trailingOrder.StopPrice = stopPrice
+		stopBooks.Put(trailingOrder.Key(), trailingOrder)
+
+		log.Debugf("[oceanbook.orderbook] trailing stop order %d moves stop price to %s", trailingOrder.ID, stopPrice)
+	}
+}
+
+// indexExpiry adds order with expiry time into the expiry index.
+func (od *OrderBook) indexExpiry(o *order.Order) {
+	if o.ExpiresAt.IsZero() {
 		return
 	}
 
-	takerBooks.Put(newOrder.Key(), newOrder)
+	od.expiries.Put(o.Key(), o)
+}
+
+// expireOrders removes orders expired by now from orderbook and returns them.
+func (od *OrderBook) expireOrders() []*order.Order {
+	expiredOrders := []*order.Order{}
+
+	now := od.clock.Now()
+	for {
+		first := od.expiries.Left()
+		if first == nil {
+			break
+		}
+
+		expiredOrder := first.Value.(*order.Order)
+		if !expiredOrder.Expired(now) {
+			break
+		}
+
+		od.removeOrder(expiredOrder)
+		od.unlinkOrder(expiredOrder)
+		od.reportState(expiredOrder, order.StateExpired, "")
+		expiredOrders = append(expiredOrders, expiredOrder)
+
+		log.Infof("[oceanbook.orderbook] order %d expired at %s", expiredOrder.ID, expiredOrder.ExpiresAt)
+	}
+
+	return expiredOrders
 }
 
 func (od *OrderBook) setMarketPrice(newPrice decimal.Decimal) {
 	previousPrice := od.Price
 	od.Price = newPrice
+	od.recordReferencePrice(newPrice)
+
+	od.trailStopOrders(newPrice)
 
 	if previousPrice.Equal(decimal.Zero) {
 		return
 	}
 
+	var triggeredOrders []*order.Order
 	switch {
 	case newPrice.LessThan(previousPrice):
-		// price gone done, check stop asks
-		for {
-			best := od.StopBids.Right()
-			if best == nil {
-				break
-			}
+		// price gone down, check stop orders triggered by falling price
+		for _, stopBooks := range []*rbt.Tree{od.StopBids, od.StopAsks} {
+			it := stopBooks.Iterator()
+			for it.Next() {
+				stopOrder := it.Value().(*order.Order)
+				if stopOrder.TriggerDirection() != order.TriggerFallsTo || !stopOrder.Triggered(newPrice) {
+					break
+				}
 
-			bestOrder := best.Value.(*order.Order)
-			if bestOrder.StopPrice.LessThan(newPrice) {
-				break
+				triggeredOrders = append(triggeredOrders, stopOrder)
 			}
-
-			log.Debugf("[oceanbook.orderbook] bid order %d with stop price %s enqueued", bestOrder.ID, bestOrder.Price)
-
-			od.StopBids.Remove(best.Key)
-			od.pendingOrdersQueue.Push(bestOrder)
 		}
 
 	case newPrice.GreaterThan(previousPrice):
-		// price gone done, check stop asks
-		for {
-			best := od.StopAsks.Right()
-			if best == nil {
-				break
-			}
+		// price gone up, check stop orders triggered by rising price
+		for _, stopBooks := range []*rbt.Tree{od.StopBids, od.StopAsks} {
+			it := stopBooks.Iterator()
+			for it.End(); it.Prev(); {
+				stopOrder := it.Value().(*order.Order)
+				if stopOrder.TriggerDirection() != order.TriggerRisesTo || !stopOrder.Triggered(newPrice) {
+					break
+				}
 
-			bestOrder := best.Value.(*order.Order)
-			if bestOrder.StopPrice.GreaterThan(newPrice) {
-				break
+				triggeredOrders = append(triggeredOrders, stopOrder)
 			}
-
-			log.Debugf("[oceanbook.orderbook] ask order %d with stop price %s enqueued", bestOrder.ID, bestOrder.Price)
-
-			od.StopAsks.Remove(best.Key)
-			od.pendingOrdersQueue.Push(bestOrder)
 		}
 
 	default:
 		// previous price equals to new price
 		return
 	}
+
+	// stop orders are enqueued in the order that market price passes their
+	// stop prices.
+	sort.SliceStable(triggeredOrders, func(i, j int) bool {
+		return triggeredOrders[i].StopPrice.Sub(previousPrice).Abs().LessThan(triggeredOrders[j].StopPrice.Sub(previousPrice).Abs())
+	})
+
+	// linked orders triggered by the same price movement are cancelled by the
+	// first triggered one, and they are no longer in stop books.
+	for _, triggeredOrder := range triggeredOrders {
+		if !od.removeOrder(triggeredOrder) {
+			continue
+		}
+
+		od.cancelLinkedOrders(triggeredOrder)
+
+		log.Debugf("[oceanbook.orderbook] %s order %d with stop price %s enqueued", triggeredOrder.Side, triggeredOrder.ID, triggeredOrder.StopPrice)
+
+		// triggered orders queue behind orders resting before the trigger,
+		// the same as amended and replenished orders.
+		triggeredOrder.CreatedAt = od.clock.Now()
+
+		od.reportState(triggeredOrder, order.StateTriggered, "")
+		od.pendingOrdersQueue.Push(triggeredOrder)
+		od.pendingOrders[triggeredOrder.ID] = triggeredOrder
+	}
 }
 
-// CancelOrder removes order with specified id.
-func (od *OrderBook) CancelOrder(o *order.Order) {
+// AmendOrder changes the price or quantity of the resting order with specified
+// id, zero price or quantity leaves it unchanged. The order keeps its time
+// priority when only its quantity decreases, otherwise it is inserted again
+// and may trade with makers at the new price.
+func (od *OrderBook) AmendOrder(o *order.Order) ([]*trade.Trade, error) {
 	od.Lock()
 	defer od.Unlock()
 
+	od.expireOrders()
+
 	targetOrder, ok := od.cancelOrdersQueue[o.ID]
 	if !ok {
-		return
+		return nil, ErrOrderNotFound
+	}
+
+	if err := od.allowAmend(targetOrder); err != nil {
+		return nil, err
+	}
+
+	price := targetOrder.Price
+	if o.Price.IsPositive() {
+		price = o.Price
+	}
+
+	quantity := targetOrder.Quantity
+	if o.Quantity.IsPositive() {
+		quantity = o.Quantity
+	}
+
+	if quantity.LessThanOrEqual(targetOrder.FilledQuantity) {
+		return nil, ErrInvalidAmendQuantity
+	}
+
+	amendedOrder := *targetOrder
+	amendedOrder.Price = price
+	amendedOrder.Quantity = quantity
+	if err := od.Spec.Validate(&amendedOrder); err != nil {
+		return nil, err
 	}
 
+	var makerBooks *rbt.Tree
 	switch targetOrder.Side {
 	case order.SideAsk:
-		od.Asks.Remove(targetOrder.Key())
+		makerBooks = od.Bids
 
 	case order.SideBid:
-		od.Bids.Remove(targetOrder.Key())
+		makerBooks = od.Asks
+	}
 
-	default:
-		od.Asks.Remove(targetOrder.Key())
-		od.Bids.Remove(targetOrder.Key())
+	visibleQuantity := targetOrder.VisibleQuantity()
+
+	if price.Equal(targetOrder.Price) && quantity.LessThanOrEqual(targetOrder.Quantity) {
+		targetOrder.Decrease(targetOrder.Quantity.Sub(quantity))
+		od.depth.update(targetOrder.Side, targetOrder.Price, targetOrder.VisibleQuantity().Sub(visibleQuantity), 0)
+		od.publishModify(targetOrder, visibleQuantity)
+
+		log.Debugf("[oceanbook.orderbook] order %d amended with quantity %s", targetOrder.ID, quantity)
+
+		return []*trade.Trade{}, nil
 	}
+
+	if targetOrder.PostOnly {
+		if err := od.preparePostOnlyOrder(&amendedOrder, makerBooks); err != nil {
+			return nil, err
+		}
+	}
+
+	od.removeOrder(targetOrder)
+
+	targetOrder.Price = price
+	targetOrder.Quantity = quantity
+	targetOrder.CreatedAt = od.clock.Now()
+
+	log.Debugf("[oceanbook.orderbook] order %d amended with price %s, quantity %s", targetOrder.ID, price, quantity)
+
+	trades, err := od.insertOrder(targetOrder)
+
+	return append(trades, od.insertPendingOrders()...), err
+}
+
+// ExpireOrders removes expired resting and stop orders from orderbook and
+// returns them.
+func (od *OrderBook) ExpireOrders() []*order.Order {
+	od.Lock()
+	defer od.Unlock()
+
+	return od.expireOrders()
 }
 
-// GetDepth returns the order book depth.
+// CancelOrder removes the resting or stop order with specified id, cancels
+// its linked orders and returns the snapshot of cancelled order.
+func (od *OrderBook) CancelOrder(o *order.Order) (*order.Order, error) {
+	od.Lock()
+	defer od.Unlock()
+
+	targetOrder, ok := od.openOrder(o.ID)
+	if !ok {
+		return nil, ErrOrderNotFound
+	}
+
+	od.removeOrder(targetOrder)
+	od.reportState(targetOrder, order.StateCancelled, "")
+	od.cancelLinkedOrders(targetOrder)
+
+	log.Debugf("[oceanbook.orderbook] order %d cancelled", targetOrder.ID)
+
+	snapshot := *targetOrder
+
+	return &snapshot, nil
+}
+
+// CheckDepth rebuilds depth from resting orders and returns the price levels
+// which differ from the maintained depth, it is empty when depth is
+// consistent.
+func (od *OrderBook) CheckDepth() []DepthDiff {
+	od.RLock()
+	defer od.RUnlock()
+
+	return diffDepth(buildDepth(od.Symbol, od.depth.Scale, od.Bids, od.Asks), od.depth)
+}
+
+// AggregateDepth returns the order book depth grouped by the price increment
+// and limited to the best price levels, see Depth.Aggregate.
+func (od *OrderBook) AggregateDepth(increment decimal.Decimal, limit int) *Depth {
+	od.RLock()
+	defer od.RUnlock()
+
+	return od.depth.Aggregate(increment, limit)
+}
+
+// GetDepth returns a snapshot of the order book depth.
 func (od *OrderBook) GetDepth() *Depth {
 	od.RLock()
 	defer od.RUnlock()
-	return od.depth
+
+	return od.depth.clone()
 }
@@ -9,7 +9,9 @@ import (
 
 	"io/ioutil"
 	"testing"
+	"time"
 
+	"github.com/draveness/oceanbook/pkg/clock"
 	"github.com/draveness/oceanbook/pkg/order"
 	"github.com/draveness/oceanbook/pkg/trade"
 	"github.com/shopspring/decimal"
@@ -28,7 +30,8 @@ type OrderBookEntry struct {
 
 func (ode *OrderBookEntry) Test(s *suiteOrderBookTester) {
 	s.T().Run(ode.Name, func(t *testing.T) {
-		orderBook := NewOrderBook("market")
+		now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+		orderBook := NewOrderBook("market", WithClock(clock.NewFakeClock(now)))
 
 		var trades []*trade.Trade
 		for _, o := range ode.Orders {
@@ -54,15 +57,27 @@ func (ode *OrderBookEntry) Test(s *suiteOrderBookTester) {
 				stopPrice, _ = decimal.NewFromString(result[4])
 			}
 
+			var trigger order.Trigger
+			if len(result) >= 6 {
+				switch result[5] {
+				case "RISES_TO":
+					trigger = order.TriggerRisesTo
+				case "FALLS_TO":
+					trigger = order.TriggerFallsTo
+				}
+			}
+
 			newOrder := &order.Order{
 				ID:        uint64(id),
 				Side:      side,
 				Price:     price,
 				Quantity:  quantity,
 				StopPrice: stopPrice,
+				Trigger:   trigger,
 			}
 
-			newTrades := orderBook.InsertOrder(newOrder)
+			newTrades, err := orderBook.InsertOrder(newOrder)
+			s.NoError(err)
 			if len(newTrades) > 0 {
 				trades = append(trades, newTrades...)
 			}
@@ -81,10 +96,12 @@ func (ode *OrderBookEntry) Test(s *suiteOrderBookTester) {
 			makeID, _ := strconv.Atoi(result[2])
 			takerID, _ := strconv.Atoi(result[3])
 			expectedTrades = append(expectedTrades, &trade.Trade{
-				Price:    price,
-				Quantity: quantity,
-				MakerID:  uint64(makeID),
-				TakerID:  uint64(takerID),
+				ID:        uint64(len(expectedTrades) + 1),
+				Price:     price,
+				Quantity:  quantity,
+				MakerID:   uint64(makeID),
+				TakerID:   uint64(takerID),
+				CreatedAt: now,
 			})
 		}
 
@@ -118,11 +135,89 @@ func (s *suiteOrderBookTester) TestInsertLimitOrder() {
 		Quantity: decimal.NewFromFloat(30.0),
 	}
 
-	s.EqualValues([]*trade.Trade{}, orderBook.InsertOrder(limitOrder))
+	trades, err := orderBook.InsertOrder(limitOrder)
+	s.NoError(err)
+	s.EqualValues([]*trade.Trade{}, trades)
 	s.EqualValues(limitOrder, orderBook.Bids.Right().Value.(*order.Order))
 	s.EqualValues(1, orderBook.Bids.Size())
 }
 
+func (s *suiteOrderBookTester) TestInsertDuplicateOrder() {
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	fakeClock := clock.NewFakeClock(now)
+	orderBook := NewOrderBook("market", WithClock(fakeClock))
+
+	insertOrder := func(side order.Side, price float64) error {
+		fakeClock.Add(time.Second)
+		_, err := orderBook.InsertOrder(&order.Order{
+			ID:       1,
+			Side:     side,
+			Price:    decimal.NewFromFloat(price),
+			Quantity: decimal.NewFromFloat(1.0),
+		})
+
+		return err
+	}
+
+	s.NoError(insertOrder(order.SideBid, 10.0))
+
+	s.Run("same price", func() {
+		s.Equal(ErrDuplicateOrder, insertOrder(order.SideBid, 10.0))
+		s.EqualValues(1, orderBook.Bids.Size())
+	})
+
+	s.Run("other side", func() {
+		s.Equal(ErrDuplicateOrder, insertOrder(order.SideAsk, 11.0))
+		s.True(orderBook.Asks.Empty())
+	})
+
+	_, err := orderBook.CancelOrder(&order.Order{ID: 1})
+	s.NoError(err)
+	s.True(orderBook.Bids.Empty())
+}
+
+func (s *suiteOrderBookTester) TestInsertOrderWithStaleMaker() {
+	orderBook := NewOrderBook("market")
+
+	orderBook.InsertOrder(&order.Order{
+		ID:       1,
+		Side:     order.SideAsk,
+		Price:    decimal.NewFromFloat(10.0),
+		Quantity: decimal.NewFromFloat(1.0),
+	})
+	orderBook.InsertOrder(&order.Order{
+		ID:       2,
+		Side:     order.SideAsk,
+		Price:    decimal.NewFromFloat(11.0),
+		Quantity: decimal.NewFromFloat(1.0),
+	})
+
+	// the maker is left in books without being indexed.
+	delete(orderBook.cancelOrdersQueue, 1)
+
+	done := make(chan []*trade.Trade)
+	go func() {
+		trades, _ := orderBook.InsertOrder(&order.Order{
+			ID:       3,
+			Side:     order.SideBid,
+			Price:    decimal.NewFromFloat(11.0),
+			Quantity: decimal.NewFromFloat(1.0),
+		})
+		done <- trades
+	}()
+
+	select {
+	case trades := <-done:
+		s.Len(trades, 1)
+		s.EqualValues(2, trades[0].MakerID)
+		s.True(orderBook.Asks.Empty())
+		s.Empty(orderBook.CheckDepth())
+
+	case <-time.After(time.Second):
+		s.FailNow("insert order never returns")
+	}
+}
+
 func (s *suiteOrderBookTester) TestInsertImmediateOrCancelOrder() {
 	orderBook := NewOrderBook("market")
 
@@ -134,45 +229,1777 @@ func (s *suiteOrderBookTester) TestInsertImmediateOrCancelOrder() {
 		ImmediateOrCancel: true,
 	}
 
-	s.EqualValues([]*trade.Trade{}, orderBook.InsertOrder(iocOrder))
+	trades, err := orderBook.InsertOrder(iocOrder)
+	s.NoError(err)
+	s.EqualValues([]*trade.Trade{}, trades)
 	s.True(orderBook.Bids.Empty())
 	s.True(orderBook.Asks.Empty())
 }
 
-func (s *suiteOrderBookTester) TestCancelOrder() {
+func (s *suiteOrderBookTester) TestInsertFillOrKillOrder() {
 	orderBook := NewOrderBook("market")
 
-	bidOrder := &order.Order{
+	orderBook.InsertOrder(&order.Order{
+		ID:       1,
+		Side:     order.SideAsk,
+		Price:    decimal.NewFromFloat(10.0),
+		Quantity: decimal.NewFromFloat(10.0),
+	})
+	orderBook.InsertOrder(&order.Order{
+		ID:       2,
+		Side:     order.SideAsk,
+		Price:    decimal.NewFromFloat(11.0),
+		Quantity: decimal.NewFromFloat(10.0),
+	})
+
+	fokOrder := &order.Order{
+		ID:         3,
+		Side:       order.SideBid,
+		Price:      decimal.NewFromFloat(10.0),
+		Quantity:   decimal.NewFromFloat(15.0),
+		FillOrKill: true,
+	}
+
+	trades, err := orderBook.InsertOrder(fokOrder)
+	s.NoError(err)
+	s.EqualValues([]*trade.Trade{}, trades)
+	s.True(fokOrder.FilledQuantity.IsZero())
+	s.True(orderBook.Bids.Empty())
+	s.EqualValues(2, orderBook.Asks.Size())
+	s.True(orderBook.Asks.Right().Value.(*order.Order).FilledQuantity.IsZero())
+
+	fokOrder = &order.Order{
+		ID:         4,
+		Side:       order.SideBid,
+		Price:      decimal.NewFromFloat(11.0),
+		Quantity:   decimal.NewFromFloat(15.0),
+		FillOrKill: true,
+	}
+
+	trades, err = orderBook.InsertOrder(fokOrder)
+	s.NoError(err)
+	s.Len(trades, 2)
+	s.True(fokOrder.Filled())
+	s.True(orderBook.Bids.Empty())
+	s.EqualValues(1, orderBook.Asks.Size())
+}
+
+func (s *suiteOrderBookTester) TestInsertPostOnlyOrder() {
+	orderBook := NewOrderBook("market", WithTickSize(decimal.NewFromFloat(0.1)))
+
+	orderBook.InsertOrder(&order.Order{
 		ID:       1,
+		Side:     order.SideAsk,
+		Price:    decimal.NewFromFloat(10.0),
+		Quantity: decimal.NewFromFloat(10.0),
+	})
+
+	postOnlyOrder := &order.Order{
+		ID:       2,
 		Side:     order.SideBid,
+		Price:    decimal.NewFromFloat(10.5),
+		Quantity: decimal.NewFromFloat(5.0),
+		PostOnly: true,
+	}
+
+	trades, err := orderBook.InsertOrder(postOnlyOrder)
+	s.Equal(ErrPostOnlyOrderWouldCross, err)
+	s.Empty(trades)
+	s.True(orderBook.Bids.Empty())
+
+	postOnlyOrder = &order.Order{
+		ID:            3,
+		Side:          order.SideBid,
+		Price:         decimal.NewFromFloat(10.5),
+		Quantity:      decimal.NewFromFloat(5.0),
+		PostOnly:      true,
+		PostOnlySlide: true,
+	}
+
+	trades, err = orderBook.InsertOrder(postOnlyOrder)
+	s.NoError(err)
+	s.Empty(trades)
+	s.True(decimal.NewFromFloat(9.9).Equal(postOnlyOrder.Price))
+	s.EqualValues(postOnlyOrder, orderBook.Bids.Right().Value.(*order.Order))
+
+	postOnlyOrder = &order.Order{
+		ID:       4,
+		Side:     order.SideAsk,
 		Price:    decimal.NewFromFloat(10.0),
-		Quantity: decimal.NewFromFloat(30.0),
+		Quantity: decimal.NewFromFloat(5.0),
+		PostOnly: true,
 	}
 
+	trades, err = orderBook.InsertOrder(postOnlyOrder)
+	s.NoError(err)
+	s.Empty(trades)
+	s.EqualValues(2, orderBook.Asks.Size())
+
+	trades, err = orderBook.InsertOrder(&order.Order{
+		ID:       5,
+		Side:     order.SideAsk,
+		Quantity: decimal.NewFromFloat(5.0),
+		PostOnly: true,
+	})
+	s.Equal(ErrInvalidPostOnlyOrder, err)
+	s.Empty(trades)
+}
+
+func (s *suiteOrderBookTester) TestInsertIcebergOrder() {
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	fakeClock := clock.NewFakeClock(now)
+	orderBook := NewOrderBook("market", WithClock(fakeClock))
+
+	icebergOrder := &order.Order{
+		ID:              1,
+		Side:            order.SideAsk,
+		Price:           decimal.NewFromFloat(10.0),
+		Quantity:        decimal.NewFromFloat(10.0),
+		DisplayQuantity: decimal.NewFromFloat(2.0),
+	}
 	askOrder := &order.Order{
 		ID:       2,
 		Side:     order.SideAsk,
 		Price:    decimal.NewFromFloat(10.0),
-		Quantity: decimal.NewFromFloat(30.0),
+		Quantity: decimal.NewFromFloat(3.0),
 	}
 
-	orderBook.InsertOrder(bidOrder)
+	orderBook.InsertOrder(icebergOrder)
+	fakeClock.Add(time.Second)
 	orderBook.InsertOrder(askOrder)
+	s.True(decimal.NewFromFloat(2.0).Equal(icebergOrder.VisibleQuantity()))
 
-	orderBook.CancelOrder(bidOrder)
-	s.Nil(orderBook.Bids.Right())
-	s.EqualValues(0, orderBook.Bids.Size())
+	fakeClock.Add(time.Second)
+	trades, err := orderBook.InsertOrder(&order.Order{
+		ID:       3,
+		Side:     order.SideBid,
+		Price:    decimal.NewFromFloat(10.0),
+		Quantity: decimal.NewFromFloat(3.0),
+	})
+	s.NoError(err)
+	s.EqualValues([]*trade.Trade{
+		{
+			ID:       1,
+			Price:    decimal.NewFromFloat(10.0),
+			Quantity: decimal.NewFromFloat(2.0),
+			MakerID:  1,
+			TakerID:  3,
 
-	orderBook.CancelOrder(askOrder)
-	s.Nil(orderBook.Asks.Right())
-	s.EqualValues(0, orderBook.Asks.Size())
+			CreatedAt: fakeClock.Now(),
+		},
+		{
+			ID:       2,
+			Price:    decimal.NewFromFloat(10.0),
+			Quantity: decimal.NewFromFloat(1.0),
+			MakerID:  2,
+			TakerID:  3,
 
-	orderBook.InsertOrder(bidOrder)
-	orderBook.CancelOrder(&order.Order{
-		ID: 1,
+			CreatedAt: fakeClock.Now(),
+		},
+	}, trades)
+
+	s.True(decimal.NewFromFloat(2.0).Equal(icebergOrder.VisibleQuantity()))
+	s.True(decimal.NewFromFloat(8.0).Equal(icebergOrder.PendingQuantity()))
+	s.EqualValues(askOrder, orderBook.Asks.Right().Value.(*order.Order))
+	s.EqualValues(2, orderBook.Asks.Size())
+}
+
+func (s *suiteOrderBookTester) TestAmendOrder() {
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	fakeClock := clock.NewFakeClock(now)
+	orderBook := NewOrderBook("market", WithClock(fakeClock))
+
+	firstOrder := &order.Order{
+		ID:       1,
+		Side:     order.SideBid,
+		Price:    decimal.NewFromFloat(10.0),
+		Quantity: decimal.NewFromFloat(10.0),
+	}
+	secondOrder := &order.Order{
+		ID:       2,
+		Side:     order.SideBid,
+		Price:    decimal.NewFromFloat(10.0),
+		Quantity: decimal.NewFromFloat(10.0),
+	}
+
+	orderBook.InsertOrder(firstOrder)
+	fakeClock.Add(time.Second)
+	orderBook.InsertOrder(secondOrder)
+
+	trades, err := orderBook.AmendOrder(&order.Order{ID: 3, Quantity: decimal.NewFromFloat(5.0)})
+	s.Equal(ErrOrderNotFound, err)
+	s.Nil(trades)
+
+	trades, err = orderBook.AmendOrder(&order.Order{ID: 1, Quantity: decimal.NewFromFloat(5.0)})
+	s.NoError(err)
+	s.Empty(trades)
+	s.True(decimal.NewFromFloat(5.0).Equal(firstOrder.Quantity))
+	s.EqualValues(firstOrder, orderBook.Bids.Right().Value.(*order.Order))
+
+	fakeClock.Add(time.Second)
+	trades, err = orderBook.AmendOrder(&order.Order{ID: 1, Quantity: decimal.NewFromFloat(8.0)})
+	s.NoError(err)
+	s.Empty(trades)
+	s.True(decimal.NewFromFloat(8.0).Equal(firstOrder.Quantity))
+	s.EqualValues(secondOrder, orderBook.Bids.Right().Value.(*order.Order))
+	s.EqualValues(2, orderBook.Bids.Size())
+
+	orderBook.InsertOrder(&order.Order{
+		ID:       4,
+		Side:     order.SideAsk,
+		Price:    decimal.NewFromFloat(11.0),
+		Quantity: decimal.NewFromFloat(4.0),
 	})
-	s.Nil(orderBook.Bids.Right())
-	s.EqualValues(0, orderBook.Bids.Size())
+
+	trades, err = orderBook.AmendOrder(&order.Order{ID: 1, Price: decimal.NewFromFloat(11.0)})
+	s.NoError(err)
+	s.EqualValues([]*trade.Trade{
+		{
+			ID:       1,
+			Price:    decimal.NewFromFloat(11.0),
+			Quantity: decimal.NewFromFloat(4.0),
+			MakerID:  4,
+			TakerID:  1,
+
+			CreatedAt: fakeClock.Now(),
+		},
+	}, trades)
+	s.EqualValues(firstOrder, orderBook.Bids.Right().Value.(*order.Order))
+	s.True(orderBook.Asks.Empty())
+
+	trades, err = orderBook.AmendOrder(&order.Order{ID: 1, Quantity: decimal.NewFromFloat(4.0)})
+	s.Equal(ErrInvalidAmendQuantity, err)
+	s.Nil(trades)
+}
+
+func (s *suiteOrderBookTester) TestTimePriority() {
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	fakeClock := clock.NewFakeClock(now)
+	orderBook := NewOrderBook("market", WithClock(fakeClock))
+
+	newOrder := func(id uint64, quantity, displayQuantity float64) *order.Order {
+		return &order.Order{
+			ID:              id,
+			Side:            order.SideBid,
+			Price:           decimal.NewFromFloat(10.0),
+			Quantity:        decimal.NewFromFloat(quantity),
+			DisplayQuantity: decimal.NewFromFloat(displayQuantity),
+		}
+	}
+
+	orderBook.InsertOrder(newOrder(1, 1.0, 0))
+	fakeClock.Add(time.Second)
+	_, err := orderBook.AmendOrder(&order.Order{ID: 1, Quantity: decimal.NewFromFloat(2.0)})
+	s.NoError(err)
+
+	fakeClock.Add(time.Second)
+	orderBook.InsertOrder(newOrder(2, 1.0, 0))
+	s.EqualValues(1, orderBook.Bids.Right().Value.(*order.Order).ID)
+
+	orderBook.CancelOrder(&order.Order{ID: 1})
+	orderBook.CancelOrder(&order.Order{ID: 2})
+
+	fakeClock.Add(time.Second)
+	orderBook.InsertOrder(newOrder(3, 2.0, 1.0))
+	fakeClock.Add(time.Second)
+	orderBook.InsertOrder(newOrder(4, 1.0, 0))
+
+	fakeClock.Add(time.Second)
+	trades, _ := orderBook.InsertOrder(&order.Order{
+		ID:       5,
+		Side:     order.SideAsk,
+		Price:    decimal.NewFromFloat(10.0),
+		Quantity: decimal.NewFromFloat(1.0),
+	})
+	s.Len(trades, 1)
+	s.EqualValues(3, trades[0].MakerID)
+
+	fakeClock.Add(time.Second)
+	orderBook.InsertOrder(newOrder(6, 1.0, 0))
+
+	it := orderBook.Bids.Iterator()
+	ids := []uint64{}
+	for it.End(); it.Prev(); {
+		ids = append(ids, it.Value().(*order.Order).ID)
+	}
+	s.Equal([]uint64{4, 3, 6}, ids)
+}
+
+func (s *suiteOrderBookTester) TestSelfTradePrevention() {
+	tests := []struct {
+		name                string
+		selfTradePrevention order.SelfTradePrevention
+		takerQuantity       float64
+		makerPending        float64
+		takerPending        float64
+		asksSize            int
+		bidsSize            int
+	}{
+		{
+			name:                "CancelNewest",
+			selfTradePrevention: order.SelfTradePreventionCancelNewest,
+			takerQuantity:       3.0,
+			makerPending:        5.0,
+			takerPending:        3.0,
+			asksSize:            1,
+			bidsSize:            0,
+		},
+		{
+			name:                "CancelOldest",
+			selfTradePrevention: order.SelfTradePreventionCancelOldest,
+			takerQuantity:       3.0,
+			makerPending:        5.0,
+			takerPending:        3.0,
+			asksSize:            0,
+			bidsSize:            1,
+		},
+		{
+			name:                "CancelBoth",
+			selfTradePrevention: order.SelfTradePreventionCancelBoth,
+			takerQuantity:       3.0,
+			makerPending:        5.0,
+			takerPending:        3.0,
+			asksSize:            0,
+			bidsSize:            0,
+		},
+		{
+			name:                "DecrementAndCancelTaker",
+			selfTradePrevention: order.SelfTradePreventionDecrementAndCancel,
+			takerQuantity:       3.0,
+			makerPending:        2.0,
+			takerPending:        0.0,
+			asksSize:            1,
+			bidsSize:            0,
+		},
+		{
+			name:                "DecrementAndCancelMaker",
+			selfTradePrevention: order.SelfTradePreventionDecrementAndCancel,
+			takerQuantity:       8.0,
+			makerPending:        0.0,
+			takerPending:        3.0,
+			asksSize:            0,
+			bidsSize:            1,
+		},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			orderBook := NewOrderBook("market")
+
+			makerOrder := &order.Order{
+				ID:       1,
+				Side:     order.SideAsk,
+				Price:    decimal.NewFromFloat(10.0),
+				Quantity: decimal.NewFromFloat(5.0),
+				OwnerID:  1,
+			}
+			takerOrder := &order.Order{
+				ID:                  2,
+				Side:                order.SideBid,
+				Price:               decimal.NewFromFloat(10.0),
+				Quantity:            decimal.NewFromFloat(test.takerQuantity),
+				OwnerID:             1,
+				SelfTradePrevention: test.selfTradePrevention,
+			}
+
+			orderBook.InsertOrder(makerOrder)
+			trades, err := orderBook.InsertOrder(takerOrder)
+			s.NoError(err)
+			s.Empty(trades)
+
+			s.True(decimal.NewFromFloat(test.makerPending).Equal(makerOrder.PendingQuantity()))
+			s.True(decimal.NewFromFloat(test.takerPending).Equal(takerOrder.PendingQuantity()))
+			s.Equal(test.asksSize, orderBook.Asks.Size())
+			s.Equal(test.bidsSize, orderBook.Bids.Size())
+		})
+	}
+}
+
+func (s *suiteOrderBookTester) TestExpireOrders() {
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	fakeClock := clock.NewFakeClock(now)
+	orderBook := NewOrderBook("market", WithClock(fakeClock))
+
+	limitOrder := &order.Order{
+		ID:        1,
+		Side:      order.SideBid,
+		Price:     decimal.NewFromFloat(10.0),
+		Quantity:  decimal.NewFromFloat(10.0),
+		ExpiresAt: now.Add(10 * time.Second),
+	}
+	stopOrder := &order.Order{
+		ID:        2,
+		Side:      order.SideAsk,
+		Price:     decimal.NewFromFloat(12.0),
+		StopPrice: decimal.NewFromFloat(11.0),
+		Quantity:  decimal.NewFromFloat(10.0),
+		ExpiresAt: now.Add(5 * time.Second),
+	}
+
+	orderBook.InsertOrder(limitOrder)
+	orderBook.InsertOrder(stopOrder)
+	s.EqualValues(1, orderBook.Bids.Size())
+	s.EqualValues(1, orderBook.StopAsks.Size())
+	s.Empty(orderBook.ExpireOrders())
+
+	fakeClock.Add(5 * time.Second)
+	s.Equal([]*order.Order{stopOrder}, orderBook.ExpireOrders())
+	s.True(orderBook.StopAsks.Empty())
+	s.EqualValues(1, orderBook.Bids.Size())
+
+	fakeClock.Add(5 * time.Second)
+	trades, err := orderBook.InsertOrder(&order.Order{
+		ID:       3,
+		Side:     order.SideAsk,
+		Price:    decimal.NewFromFloat(10.0),
+		Quantity: decimal.NewFromFloat(10.0),
+	})
+	s.NoError(err)
+	s.Empty(trades)
+	s.True(orderBook.Bids.Empty())
+	s.EqualValues(1, orderBook.Asks.Size())
+
+	trades, err = orderBook.InsertOrder(&order.Order{
+		ID:        4,
+		Side:      order.SideBid,
+		Price:     decimal.NewFromFloat(10.0),
+		Quantity:  decimal.NewFromFloat(10.0),
+		ExpiresAt: now,
+	})
+	s.Equal(ErrOrderExpired, err)
+	s.Empty(trades)
+	s.EqualValues(1, orderBook.Asks.Size())
+}
+
+func (s *suiteOrderBookTester) TestInsertStopOrder() {
+	orderBook := NewOrderBook("market")
+
+	orderBook.InsertOrder(&order.Order{
+		ID:       1,
+		Side:     order.SideAsk,
+		Price:    decimal.NewFromFloat(10.0),
+		Quantity: decimal.NewFromFloat(1.0),
+	})
+	orderBook.InsertOrder(&order.Order{
+		ID:       2,
+		Side:     order.SideBid,
+		Price:    decimal.NewFromFloat(10.0),
+		Quantity: decimal.NewFromFloat(1.0),
+	})
+	s.True(decimal.NewFromFloat(10.0).Equal(orderBook.Price))
+
+	trades, err := orderBook.InsertOrder(&order.Order{
+		ID:        3,
+		Side:      order.SideBid,
+		Price:     decimal.NewFromFloat(12.0),
+		Quantity:  decimal.NewFromFloat(1.0),
+		StopPrice: decimal.NewFromFloat(9.0),
+		Trigger:   order.TriggerRisesTo,
+	})
+	s.Equal(ErrStopOrderWouldTrigger, err)
+	s.Empty(trades)
+
+	trades, err = orderBook.InsertOrder(&order.Order{
+		ID:        4,
+		Side:      order.SideBid,
+		Price:     decimal.NewFromFloat(12.0),
+		Quantity:  decimal.NewFromFloat(1.0),
+		StopPrice: decimal.NewFromFloat(11.0),
+		Trigger:   order.TriggerRisesTo,
+		StopType:  order.StopTypeMarket,
+	})
+	s.Equal(ErrInvalidStopOrder, err)
+	s.Empty(trades)
+
+	stopMarketOrder := &order.Order{
+		ID:        5,
+		Side:      order.SideBid,
+		Quantity:  decimal.NewFromFloat(1.0),
+		StopPrice: decimal.NewFromFloat(11.0),
+		Trigger:   order.TriggerRisesTo,
+	}
+	trades, err = orderBook.InsertOrder(stopMarketOrder)
+	s.NoError(err)
+	s.Empty(trades)
+	s.Equal(order.StopTypeMarket, stopMarketOrder.StopType)
+
+	stopLimitOrder := &order.Order{
+		ID:        6,
+		Side:      order.SideBid,
+		Price:     decimal.NewFromFloat(9.0),
+		Quantity:  decimal.NewFromFloat(1.0),
+		StopPrice: decimal.NewFromFloat(9.5),
+		Trigger:   order.TriggerFallsTo,
+	}
+	trades, err = orderBook.InsertOrder(stopLimitOrder)
+	s.NoError(err)
+	s.Empty(trades)
+	s.Equal(order.StopTypeLimit, stopLimitOrder.StopType)
+
+	s.EqualValues(stopLimitOrder, orderBook.StopBids.Left().Value.(*order.Order))
+	s.EqualValues(stopMarketOrder, orderBook.StopBids.Right().Value.(*order.Order))
+}
+
+func (s *suiteOrderBookTester) TestInsertTrailingStopOrder() {
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	fakeClock := clock.NewFakeClock(now)
+	orderBook := NewOrderBook("market", WithClock(fakeClock))
+
+	match := func(id uint64, price float64) []*trade.Trade {
+		orderBook.InsertOrder(&order.Order{
+			ID:       id,
+			Side:     order.SideAsk,
+			Price:    decimal.NewFromFloat(price),
+			Quantity: decimal.NewFromFloat(1.0),
+		})
+		trades, err := orderBook.InsertOrder(&order.Order{
+			ID:       id + 1,
+			Side:     order.SideBid,
+			Price:    decimal.NewFromFloat(price),
+			Quantity: decimal.NewFromFloat(1.0),
+		})
+		s.NoError(err)
+		return trades
+	}
+
+	trailingOrder := &order.Order{
+		ID:             1,
+		Side:           order.SideAsk,
+		Quantity:       decimal.NewFromFloat(1.0),
+		Trigger:        order.TriggerFallsTo,
+		TrailingAmount: decimal.NewFromFloat(1.0),
+	}
+
+	_, err := orderBook.InsertOrder(trailingOrder)
+	s.Equal(ErrInvalidTrailingStopOrder, err)
+
+	match(10, 10.0)
+	orderBook.InsertOrder(&order.Order{
+		ID:       3,
+		Side:     order.SideBid,
+		Price:    decimal.NewFromFloat(8.0),
+		Quantity: decimal.NewFromFloat(10.0),
+	})
+
+	_, err = orderBook.InsertOrder(trailingOrder)
+	s.NoError(err)
+	s.True(decimal.NewFromFloat(9.0).Equal(trailingOrder.StopPrice))
+
+	match(20, 12.0)
+	s.True(decimal.NewFromFloat(11.0).Equal(trailingOrder.StopPrice))
+
+	match(30, 11.5)
+	s.True(decimal.NewFromFloat(11.0).Equal(trailingOrder.StopPrice))
+	s.EqualValues(1, orderBook.StopAsks.Size())
+
+	trades := match(40, 11.0)
+	s.Len(trades, 2)
+	s.EqualValues(&trade.Trade{
+		ID:       5,
+		Price:    decimal.NewFromFloat(8.0),
+		Quantity: decimal.NewFromFloat(1.0),
+		MakerID:  3,
+		TakerID:  1,
+
+		CreatedAt: now,
+	}, trades[1])
+	s.True(orderBook.StopAsks.Empty())
+	s.Empty(orderBook.trailingOrders)
+}
+
+func (s *suiteOrderBookTester) TestTriggeredStopOrderPriority() {
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	fakeClock := clock.NewFakeClock(now)
+	orderBook := NewOrderBook("market", WithClock(fakeClock))
+
+	insertOrder := func(o *order.Order) []*trade.Trade {
+		fakeClock.Add(time.Second)
+		trades, err := orderBook.InsertOrder(o)
+		s.NoError(err)
+
+		return trades
+	}
+
+	insertOrder(&order.Order{ID: 1, Side: order.SideAsk, Price: decimal.NewFromFloat(9.0), Quantity: decimal.NewFromFloat(1.0)})
+	insertOrder(&order.Order{ID: 2, Side: order.SideBid, Price: decimal.NewFromFloat(9.0), Quantity: decimal.NewFromFloat(1.0)})
+
+	insertOrder(&order.Order{
+		ID:        3,
+		Side:      order.SideBid,
+		Price:     decimal.NewFromFloat(10.0),
+		Quantity:  decimal.NewFromFloat(1.0),
+		StopPrice: decimal.NewFromFloat(10.5),
+		Trigger:   order.TriggerRisesTo,
+	})
+	insertOrder(&order.Order{ID: 4, Side: order.SideBid, Price: decimal.NewFromFloat(10.0), Quantity: decimal.NewFromFloat(1.0)})
+
+	insertOrder(&order.Order{ID: 5, Side: order.SideAsk, Price: decimal.NewFromFloat(11.0), Quantity: decimal.NewFromFloat(1.0)})
+	insertOrder(&order.Order{ID: 6, Side: order.SideBid, Price: decimal.NewFromFloat(11.0), Quantity: decimal.NewFromFloat(1.0)})
+	s.EqualValues(2, orderBook.Bids.Size())
+
+	// the triggered stop order queues behind the order resting before.
+	trades := insertOrder(&order.Order{ID: 7, Side: order.SideAsk, Price: decimal.NewFromFloat(10.0), Quantity: decimal.NewFromFloat(1.0)})
+	s.Len(trades, 1)
+	s.EqualValues(4, trades[0].MakerID)
+}
+
+func (s *suiteOrderBookTester) TestInsertOCOOrder() {
+	orderBook := NewOrderBook("market")
+
+	orderBook.InsertOrder(&order.Order{
+		ID:       1,
+		Side:     order.SideAsk,
+		Price:    decimal.NewFromFloat(10.0),
+		Quantity: decimal.NewFromFloat(1.0),
+	})
+	orderBook.InsertOrder(&order.Order{
+		ID:       2,
+		Side:     order.SideBid,
+		Price:    decimal.NewFromFloat(10.0),
+		Quantity: decimal.NewFromFloat(1.0),
+	})
+	orderBook.InsertOrder(&order.Order{
+		ID:       3,
+		Side:     order.SideBid,
+		Price:    decimal.NewFromFloat(9.0),
+		Quantity: decimal.NewFromFloat(1.0),
+	})
+	orderBook.InsertOrder(&order.Order{
+		ID:       4,
+		Side:     order.SideBid,
+		Price:    decimal.NewFromFloat(8.0),
+		Quantity: decimal.NewFromFloat(1.0),
+	})
+
+	takeProfit := func(id, groupID uint64) *order.Order {
+		return &order.Order{
+			ID:       id,
+			GroupID:  groupID,
+			Side:     order.SideAsk,
+			Price:    decimal.NewFromFloat(12.0),
+			Quantity: decimal.NewFromFloat(1.0),
+		}
+	}
+	stopLoss := func(id uint64, stopPrice float64) *order.Order {
+		return &order.Order{
+			ID:        id,
+			Side:      order.SideAsk,
+			Quantity:  decimal.NewFromFloat(1.0),
+			StopPrice: decimal.NewFromFloat(stopPrice),
+			Trigger:   order.TriggerFallsTo,
+		}
+	}
+
+	trades, err := orderBook.InsertOCOOrder(takeProfit(10, 0), &order.Order{
+		ID:                11,
+		Side:              order.SideAsk,
+		Price:             decimal.NewFromFloat(13.0),
+		Quantity:          decimal.NewFromFloat(1.0),
+		ImmediateOrCancel: true,
+	})
+	s.Equal(ErrInvalidOCOOrder, err)
+	s.Empty(trades)
+	s.Equal(0, orderBook.Asks.Size())
+
+	// the first order is removed when the second one is rejected.
+	trades, err = orderBook.InsertOCOOrder(takeProfit(10, 0), stopLoss(11, 10.5))
+	s.Equal(ErrStopOrderWouldTrigger, err)
+	s.Empty(trades)
+	s.Equal(0, orderBook.Asks.Size())
+	s.Empty(orderBook.ocoGroups)
+
+	// fill of the take profit order cancels the stop loss order.
+	trades, err = orderBook.InsertOCOOrder(takeProfit(10, 0), stopLoss(11, 9.5))
+	s.NoError(err)
+	s.Empty(trades)
+	s.Equal(1, orderBook.Asks.Size())
+	s.Equal(1, orderBook.StopAsks.Size())
+
+	trades, err = orderBook.InsertOCOOrder(takeProfit(12, 10), stopLoss(13, 9.5))
+	s.Equal(ErrInvalidOCOOrder, err)
+	s.Empty(trades)
+
+	trades, err = orderBook.InsertOrder(&order.Order{
+		ID:       14,
+		Side:     order.SideBid,
+		Price:    decimal.NewFromFloat(12.0),
+		Quantity: decimal.NewFromFloat(0.5),
+	})
+	s.NoError(err)
+	s.Len(trades, 1)
+	s.Equal(1, orderBook.Asks.Size())
+	s.Equal(0, orderBook.StopAsks.Size())
+	s.Empty(orderBook.ocoGroups)
+
+	orderBook.CancelOrder(&order.Order{ID: 10})
+
+	// trigger of the stop loss order cancels the take profit order.
+	trades, err = orderBook.InsertOCOOrder(takeProfit(20, 200), stopLoss(21, 9.5))
+	s.NoError(err)
+	s.Empty(trades)
+
+	trades, err = orderBook.InsertOrder(&order.Order{
+		ID:       22,
+		Side:     order.SideAsk,
+		Price:    decimal.NewFromFloat(9.0),
+		Quantity: decimal.NewFromFloat(1.0),
+	})
+	s.NoError(err)
+	s.Len(trades, 2)
+	s.Equal(uint64(22), trades[0].TakerID)
+	s.Equal(uint64(3), trades[0].MakerID)
+	s.Equal(uint64(21), trades[1].TakerID)
+	s.Equal(uint64(4), trades[1].MakerID)
+	s.Equal(0, orderBook.Asks.Size())
+	s.Equal(0, orderBook.StopAsks.Size())
+	s.Empty(orderBook.ocoGroups)
+}
+
+func (s *suiteOrderBookTester) TestUnlinkOCOOrder() {
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	fakeClock := clock.NewFakeClock(now)
+
+	reports := []*order.Report{}
+	orderBook := NewOrderBook("market", WithClock(fakeClock), WithReporter(func(report *order.Report) {
+		reports = append(reports, report)
+	}))
+
+	askOrder := func(id uint64, price float64) *order.Order {
+		return &order.Order{
+			ID:       id,
+			GroupID:  5,
+			Side:     order.SideAsk,
+			Price:    decimal.NewFromFloat(price),
+			Quantity: decimal.NewFromFloat(1.0),
+		}
+	}
+
+	expiringOrder := askOrder(1, 12.0)
+	expiringOrder.ExpiresAt = now.Add(10 * time.Second)
+	_, err := orderBook.InsertOCOOrder(expiringOrder, askOrder(2, 13.0))
+	s.NoError(err)
+
+	fakeClock.Add(20 * time.Second)
+	s.Len(orderBook.ExpireOrders(), 1)
+	s.Equal(order.StateExpired, expiringOrder.State)
+	s.Empty(orderBook.ocoGroups)
+
+	// the group id is reusable once the expired order leaves the orderbook.
+	_, err = orderBook.InsertOCOOrder(askOrder(3, 14.0), askOrder(4, 15.0))
+	s.NoError(err)
+
+	reports = reports[:0]
+	trades, err := orderBook.InsertOrder(&order.Order{
+		ID:       5,
+		Side:     order.SideBid,
+		Price:    decimal.NewFromFloat(13.0),
+		Quantity: decimal.NewFromFloat(1.0),
+	})
+	s.NoError(err)
+	s.Len(trades, 1)
+	s.Equal(order.StateExpired, expiringOrder.State)
+	s.Equal(2, orderBook.Asks.Size())
+	s.Len(orderBook.ocoGroups, 1)
+
+	for _, report := range reports {
+		s.NotEqual(reasonLinkedOrder, report.Reason)
+	}
+}
+
+func (s *suiteOrderBookTester) TestInsertQuoteQuantityOrder() {
+	orderBook := NewOrderBook("market", WithLotSize(decimal.NewFromFloat(0.01)))
+
+	for id, price := range []float64{10.0, 11.0, 12.0} {
+		orderBook.InsertOrder(&order.Order{
+			ID:       uint64(id + 1),
+			Side:     order.SideAsk,
+			Price:    decimal.NewFromFloat(price),
+			Quantity: decimal.NewFromFloat(5.0),
+		})
+	}
+
+	trades, err := orderBook.InsertOrder(&order.Order{
+		ID:            4,
+		Side:          order.SideAsk,
+		QuoteQuantity: decimal.NewFromFloat(10.0),
+	})
+	s.Equal(ErrInvalidQuoteQuantityOrder, err)
+	s.Empty(trades)
+
+	quoteOrder := &order.Order{
+		ID:            5,
+		Side:          order.SideBid,
+		QuoteQuantity: decimal.NewFromFloat(140.0),
+	}
+	trades, err = orderBook.InsertOrder(quoteOrder)
+	s.NoError(err)
+	s.Len(trades, 3)
+	s.True(decimal.NewFromFloat(5.0).Equal(trades[0].Quantity))
+	s.True(decimal.NewFromFloat(5.0).Equal(trades[1].Quantity))
+	s.True(decimal.NewFromFloat(2.91).Equal(trades[2].Quantity))
+	s.True(decimal.NewFromFloat(139.92).Equal(quoteOrder.FilledQuote))
+	s.Equal(1, orderBook.Asks.Size())
+	s.True(decimal.NewFromFloat(12.0).Equal(orderBook.Price))
+
+	trades, err = orderBook.InsertOrder(&order.Order{
+		ID:            6,
+		Side:          order.SideBid,
+		QuoteQuantity: decimal.NewFromFloat(0.1),
+	})
+	s.NoError(err)
+	s.Empty(trades)
+	s.Equal(1, orderBook.Asks.Size())
+}
+
+func (s *suiteOrderBookTester) TestMarketProtection() {
+	newOrderBook := func(side order.Side, protection MarketProtection) *OrderBook {
+		orderBook := NewOrderBook("market", WithTickSize(decimal.NewFromFloat(0.1)), WithMarketProtection(protection))
+		for id, price := range []float64{10.0, 11.0, 12.0} {
+			if side == order.SideBid {
+				price = 20.0 - price
+			}
+
+			orderBook.InsertOrder(&order.Order{
+				ID:       uint64(id + 1),
+				Side:     side,
+				Price:    decimal.NewFromFloat(price),
+				Quantity: decimal.NewFromFloat(1.0),
+			})
+		}
+
+		return orderBook
+	}
+
+	orderBook := newOrderBook(order.SideAsk, MarketProtection{
+		Deviation: decimal.NewFromFloat(15.0),
+		Reference: ProtectionReferenceLastPrice,
+	})
+	trades, err := orderBook.InsertOrder(&order.Order{
+		ID:       4,
+		Side:     order.SideBid,
+		Quantity: decimal.NewFromFloat(3.0),
+	})
+	s.Equal(ErrMarketProtectionCancelled, err)
+	s.Len(trades, 2)
+	s.Equal(1, orderBook.Asks.Size())
+	s.Equal(0, orderBook.Bids.Size())
+
+	// last price is the reference after the first trade.
+	trades, err = orderBook.InsertOrder(&order.Order{
+		ID:       5,
+		Side:     order.SideBid,
+		Quantity: decimal.NewFromFloat(1.0),
+	})
+	s.NoError(err)
+	s.Len(trades, 1)
+
+	orderBook = newOrderBook(order.SideBid, MarketProtection{
+		Deviation:     decimal.NewFromFloat(15.0),
+		Reference:     ProtectionReferenceBestPrice,
+		RestRemainder: true,
+	})
+	marketOrder := &order.Order{
+		ID:       4,
+		Side:     order.SideAsk,
+		Quantity: decimal.NewFromFloat(3.0),
+	}
+	trades, err = orderBook.InsertOrder(marketOrder)
+	s.NoError(err)
+	s.Len(trades, 2)
+	s.Equal(order.StatePartiallyFilled, marketOrder.State)
+	s.Equal(1, orderBook.Bids.Size())
+	s.Equal(1, orderBook.Asks.Size())
+	s.True(decimal.NewFromFloat(8.5).Equal(marketOrder.Price))
+	s.True(decimal.NewFromFloat(1.0).Equal(marketOrder.PendingQuantity()))
+
+	orderBook = newOrderBook(order.SideBid, MarketProtection{
+		Deviation:     decimal.NewFromFloat(15.0),
+		RestRemainder: true,
+	})
+	trades, err = orderBook.InsertOrder(&order.Order{
+		ID:                4,
+		Side:              order.SideAsk,
+		Quantity:          decimal.NewFromFloat(3.0),
+		ImmediateOrCancel: true,
+	})
+	s.Equal(ErrMarketProtectionCancelled, err)
+	s.Len(trades, 2)
+	s.Equal(0, orderBook.Asks.Size())
+
+	orderBook = newOrderBook(order.SideBid, MarketProtection{
+		Deviation: decimal.NewFromFloat(15.0),
+	})
+	trades, err = orderBook.InsertOrder(&order.Order{
+		ID:         4,
+		Side:       order.SideAsk,
+		Quantity:   decimal.NewFromFloat(3.0),
+		FillOrKill: true,
+	})
+	s.NoError(err)
+	s.Empty(trades)
+	s.Equal(3, orderBook.Bids.Size())
+}
+
+func (s *suiteOrderBookTester) TestProRataMatching() {
+	orderBook := NewOrderBook("market", WithLotSize(decimal.NewFromFloat(1.0)), WithMatcher(ProRataMatcher{}))
+
+	for id, quantity := range []float64{1.0, 3.0, 6.0} {
+		orderBook.InsertOrder(&order.Order{
+			ID:       uint64(id + 1),
+			Side:     order.SideAsk,
+			Price:    decimal.NewFromFloat(10.0),
+			Quantity: decimal.NewFromFloat(quantity),
+		})
+	}
+	orderBook.InsertOrder(&order.Order{
+		ID:       4,
+		Side:     order.SideAsk,
+		Price:    decimal.NewFromFloat(11.0),
+		Quantity: decimal.NewFromFloat(1.0),
+	})
+
+	trades, err := orderBook.InsertOrder(&order.Order{
+		ID:       5,
+		Side:     order.SideBid,
+		Price:    decimal.NewFromFloat(11.0),
+		Quantity: decimal.NewFromFloat(5.0),
+	})
+	s.NoError(err)
+	s.Len(trades, 3)
+	for i, quantity := range []float64{1.0, 1.0, 3.0} {
+		s.Equal(uint64(i+1), trades[i].MakerID)
+		s.True(decimal.NewFromFloat(quantity).Equal(trades[i].Quantity))
+	}
+	s.Equal(3, orderBook.Asks.Size())
+
+	// taker larger than the price level fills all makers and moves on.
+	trades, err = orderBook.InsertOrder(&order.Order{
+		ID:       6,
+		Side:     order.SideBid,
+		Price:    decimal.NewFromFloat(11.0),
+		Quantity: decimal.NewFromFloat(6.0),
+	})
+	s.NoError(err)
+	s.Len(trades, 3)
+	s.Equal(uint64(4), trades[2].MakerID)
+	s.Equal(0, orderBook.Asks.Size())
+}
+
+func (s *suiteOrderBookTester) TestAuction() {
+	orderBook := NewOrderBook("market")
+
+	trades, err := orderBook.Uncross()
+	s.Equal(ErrAuctionNotStarted, err)
+	s.Empty(trades)
+
+	orderBook.StartAuction()
+
+	orders := []*order.Order{
+		{ID: 1, Side: order.SideBid, Price: decimal.NewFromFloat(10.2), Quantity: decimal.NewFromFloat(3.0)},
+		{ID: 2, Side: order.SideAsk, Price: decimal.NewFromFloat(9.9), Quantity: decimal.NewFromFloat(2.0)},
+		{ID: 3, Side: order.SideBid, Price: decimal.NewFromFloat(10.1), Quantity: decimal.NewFromFloat(2.0)},
+		{ID: 4, Side: order.SideAsk, Price: decimal.NewFromFloat(10.0), Quantity: decimal.NewFromFloat(4.0)},
+		{ID: 5, Side: order.SideBid, Price: decimal.NewFromFloat(10.0), Quantity: decimal.NewFromFloat(5.0)},
+		{ID: 6, Side: order.SideAsk, Price: decimal.NewFromFloat(10.1), Quantity: decimal.NewFromFloat(4.0)},
+	}
+	for _, o := range orders {
+		trades, err := orderBook.InsertOrder(o)
+		s.NoError(err)
+		s.Empty(trades)
+	}
+	s.Equal(3, orderBook.Bids.Size())
+	s.Equal(3, orderBook.Asks.Size())
+
+	trades, err = orderBook.InsertOrder(&order.Order{
+		ID:       7,
+		Side:     order.SideBid,
+		Quantity: decimal.NewFromFloat(1.0),
+	})
+	s.Equal(ErrInvalidAuctionOrder, err)
+	s.Empty(trades)
+
+	trades, err = orderBook.Uncross()
+	s.NoError(err)
+
+	quantity := decimal.Zero
+	for i, trade := range trades {
+		s.EqualValues(i+1, trade.ID)
+		s.True(decimal.NewFromFloat(10.0).Equal(trade.Price))
+		quantity = quantity.Add(trade.Quantity)
+	}
+	s.True(decimal.NewFromFloat(6.0).Equal(quantity))
+	s.True(decimal.NewFromFloat(10.0).Equal(orderBook.Price))
+	uncrossed := len(trades)
+
+	// bid 5 is partially filled and ask 6 is left.
+	s.Equal(1, orderBook.Bids.Size())
+	s.Equal(1, orderBook.Asks.Size())
+	s.True(decimal.NewFromFloat(4.0).Equal(orders[4].PendingQuantity()))
+
+	// continuous trading resumes after uncross.
+	trades, err = orderBook.InsertOrder(&order.Order{
+		ID:       8,
+		Side:     order.SideBid,
+		Price:    decimal.NewFromFloat(10.1),
+		Quantity: decimal.NewFromFloat(1.0),
+	})
+	s.NoError(err)
+	s.Len(trades, 1)
+	s.EqualValues(uncrossed+1, trades[0].ID)
+}
+
+func (s *suiteOrderBookTester) TestAuctionSelfTradePrevention() {
+	tests := []struct {
+		name                string
+		selfTradePrevention order.SelfTradePrevention
+		trades              int
+		bidsSize            int
+		asksSize            int
+	}{
+		{"CancelNewest", order.SelfTradePreventionCancelNewest, 1, 1, 0},
+		{"CancelOldest", order.SelfTradePreventionCancelOldest, 0, 0, 2},
+		{"CancelBoth", order.SelfTradePreventionCancelBoth, 0, 0, 1},
+		{"DecrementAndCancel", order.SelfTradePreventionDecrementAndCancel, 1, 0, 0},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+			fakeClock := clock.NewFakeClock(now)
+			orderBook := NewOrderBook("market", WithClock(fakeClock), WithTradingState(TradingStatePreOpen))
+
+			orders := []*order.Order{
+				{ID: 1, Side: order.SideBid, Price: decimal.NewFromFloat(10.0), Quantity: decimal.NewFromFloat(2.0), OwnerID: 9},
+				{ID: 2, Side: order.SideAsk, Price: decimal.NewFromFloat(10.0), Quantity: decimal.NewFromFloat(1.0), OwnerID: 9, SelfTradePrevention: test.selfTradePrevention},
+				{ID: 3, Side: order.SideAsk, Price: decimal.NewFromFloat(10.0), Quantity: decimal.NewFromFloat(1.0), OwnerID: 1},
+			}
+			for _, o := range orders {
+				fakeClock.Add(time.Second)
+				_, err := orderBook.InsertOrder(o)
+				s.Require().NoError(err)
+			}
+
+			trades, err := orderBook.Uncross()
+			s.NoError(err)
+			s.Len(trades, test.trades)
+			for _, t := range trades {
+				s.Equal(uint64(3), t.MakerID)
+			}
+			s.Equal(test.bidsSize, orderBook.Bids.Size())
+			s.Equal(test.asksSize, orderBook.Asks.Size())
+			s.Empty(orderBook.CheckDepth())
+		})
+	}
+}
+
+func (s *suiteOrderBookTester) TestTradingState() {
+	orderBook := NewOrderBook("market")
+	s.Equal(TradingStateContinuous, orderBook.TradingState())
+	s.Equal(ErrInvalidTradingState, orderBook.SetTradingState("unknown"))
+
+	orderBook.InsertOrder(&order.Order{
+		ID:       1,
+		Side:     order.SideAsk,
+		Price:    decimal.NewFromFloat(10.0),
+		Quantity: decimal.NewFromFloat(1.0),
+	})
+
+	s.NoError(orderBook.SetTradingState(TradingStateHalted))
+
+	trades, err := orderBook.InsertOrder(&order.Order{
+		ID:       2,
+		Side:     order.SideBid,
+		Price:    decimal.NewFromFloat(10.0),
+		Quantity: decimal.NewFromFloat(1.0),
+	})
+	s.Equal(ErrInsertNotAllowed, err)
+	s.Empty(trades)
+
+	trades, err = orderBook.AmendOrder(&order.Order{
+		ID:       1,
+		Quantity: decimal.NewFromFloat(0.5),
+	})
+	s.Equal(ErrAmendNotAllowed, err)
+	s.Empty(trades)
+
+	s.NoError(orderBook.SetTradingState(TradingStatePostOnly))
+
+	trades, err = orderBook.InsertOrder(&order.Order{
+		ID:       3,
+		Side:     order.SideBid,
+		Price:    decimal.NewFromFloat(10.0),
+		Quantity: decimal.NewFromFloat(1.0),
+	})
+	s.Equal(ErrInsertNotAllowed, err)
+	s.Empty(trades)
+
+	trades, err = orderBook.InsertOrder(&order.Order{
+		ID:       4,
+		Side:     order.SideBid,
+		Price:    decimal.NewFromFloat(10.0),
+		Quantity: decimal.NewFromFloat(1.0),
+		PostOnly: true,
+	})
+	s.Equal(ErrPostOnlyOrderWouldCross, err)
+	s.Empty(trades)
+
+	trades, err = orderBook.InsertOrder(&order.Order{
+		ID:       5,
+		Side:     order.SideBid,
+		Price:    decimal.NewFromFloat(9.0),
+		Quantity: decimal.NewFromFloat(1.0),
+		PostOnly: true,
+	})
+	s.NoError(err)
+	s.Empty(trades)
+
+	// crossed orderbook in auction should be uncrossed before continuous trading.
+	orderBook.StartAuction()
+	trades, err = orderBook.InsertOrder(&order.Order{
+		ID:       6,
+		Side:     order.SideBid,
+		Price:    decimal.NewFromFloat(11.0),
+		Quantity: decimal.NewFromFloat(1.0),
+	})
+	s.NoError(err)
+	s.Empty(trades)
+	s.Equal(ErrOrderBookCrossed, orderBook.SetTradingState(TradingStateContinuous))
+
+	trades, err = orderBook.Uncross()
+	s.NoError(err)
+	s.Len(trades, 1)
+	s.Equal(TradingStateContinuous, orderBook.TradingState())
+}
+
+func (s *suiteOrderBookTester) TestCircuitBreaker() {
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	fakeClock := clock.NewFakeClock(now)
+
+	insertOrder := func(orderBook *OrderBook, id uint64, side order.Side, price, quantity float64) ([]*trade.Trade, error) {
+		return orderBook.InsertOrder(&order.Order{
+			ID:       id,
+			Side:     side,
+			Price:    decimal.NewFromFloat(price),
+			Quantity: decimal.NewFromFloat(quantity),
+		})
+	}
+
+	s.Run("static band", func() {
+		orderBook := NewOrderBook("market", WithCircuitBreaker(CircuitBreaker{
+			Band: decimal.NewFromFloat(10.0),
+		}))
+
+		insertOrder(orderBook, 1, order.SideAsk, 100.0, 1.0)
+		trades, err := insertOrder(orderBook, 2, order.SideBid, 100.0, 1.0)
+		s.NoError(err)
+		s.Len(trades, 1)
+
+		insertOrder(orderBook, 3, order.SideAsk, 108.0, 1.0)
+		insertOrder(orderBook, 4, order.SideAsk, 115.0, 1.0)
+		trades, err = insertOrder(orderBook, 5, order.SideBid, 115.0, 2.0)
+		s.Equal(ErrCircuitBreakerTripped, err)
+		s.Len(trades, 1)
+		s.True(decimal.NewFromFloat(108.0).Equal(orderBook.Price))
+		s.Equal(TradingStateHalted, orderBook.TradingState())
+		s.EqualValues(1, orderBook.Asks.Size())
+		s.True(orderBook.Bids.Empty())
+
+		s.NoError(orderBook.SetTradingState(TradingStateContinuous))
+		trades, err = insertOrder(orderBook, 6, order.SideBid, 115.0, 1.0)
+		s.NoError(err)
+		s.Len(trades, 1)
+	})
+
+	s.Run("dynamic band", func() {
+		orderBook := NewOrderBook("market", WithClock(fakeClock), WithCircuitBreaker(CircuitBreaker{
+			Band:   decimal.NewFromFloat(10.0),
+			Window: time.Minute,
+		}))
+
+		insertOrder(orderBook, 1, order.SideAsk, 100.0, 1.0)
+		insertOrder(orderBook, 2, order.SideBid, 100.0, 1.0)
+
+		fakeClock.Add(40 * time.Second)
+		insertOrder(orderBook, 3, order.SideAsk, 108.0, 1.0)
+		insertOrder(orderBook, 4, order.SideBid, 108.0, 1.0)
+
+		fakeClock.Add(30 * time.Second)
+		insertOrder(orderBook, 5, order.SideAsk, 115.0, 1.0)
+		trades, err := insertOrder(orderBook, 6, order.SideBid, 115.0, 1.0)
+		s.NoError(err)
+		s.Len(trades, 1)
+
+		insertOrder(orderBook, 7, order.SideAsk, 120.0, 1.0)
+		trades, err = insertOrder(orderBook, 8, order.SideBid, 120.0, 1.0)
+		s.Equal(ErrCircuitBreakerTripped, err)
+		s.Empty(trades)
+		s.Equal(TradingStateHalted, orderBook.TradingState())
+	})
+
+	s.Run("triggered stop orders", func() {
+		orderBook := NewOrderBook("market", WithCircuitBreaker(CircuitBreaker{
+			Band: decimal.NewFromFloat(10.0),
+		}))
+
+		insertOrder(orderBook, 1, order.SideAsk, 100.0, 1.0)
+		insertOrder(orderBook, 2, order.SideBid, 100.0, 1.0)
+
+		insertOrder(orderBook, 3, order.SideAsk, 108.0, 1.0)
+		insertOrder(orderBook, 4, order.SideAsk, 115.0, 1.0)
+		for id, stopPrice := range map[uint64]float64{5: 105.0, 6: 106.0} {
+			_, err := orderBook.InsertOrder(&order.Order{
+				ID:        id,
+				Side:      order.SideBid,
+				Price:     decimal.NewFromFloat(109.0),
+				Quantity:  decimal.NewFromFloat(1.0),
+				StopPrice: decimal.NewFromFloat(stopPrice),
+				Trigger:   order.TriggerRisesTo,
+			})
+			s.NoError(err)
+		}
+
+		trades, err := insertOrder(orderBook, 7, order.SideBid, 115.0, 2.0)
+		s.Equal(ErrCircuitBreakerTripped, err)
+		s.Len(trades, 1)
+		s.Equal(TradingStateHalted, orderBook.TradingState())
+
+		// stop orders triggered by the trade wait until trading resumes.
+		pendingOrder, err := orderBook.GetOrder(5)
+		s.NoError(err)
+		s.EqualValues(5, pendingOrder.ID)
+		s.Len(orderBook.ListOpenOrders(OrderFilter{Sides: []order.Side{order.SideBid}}), 2)
+
+		_, err = orderBook.CancelOrder(&order.Order{ID: 5})
+		s.NoError(err)
+		_, err = orderBook.GetOrder(5)
+		s.Equal(ErrOrderNotFound, err)
+
+		s.NoError(orderBook.SetTradingState(TradingStateContinuous))
+		s.EqualValues(1, orderBook.Bids.Size())
+		restingOrder, err := orderBook.GetOrder(6)
+		s.NoError(err)
+		s.True(decimal.NewFromFloat(109.0).Equal(restingOrder.Price))
+		s.EqualValues(1, orderBook.Asks.Size())
+	})
+
+	s.Run("expired triggered stop orders", func() {
+		orderBook := NewOrderBook("market", WithClock(fakeClock), WithCircuitBreaker(CircuitBreaker{
+			Band: decimal.NewFromFloat(10.0),
+		}))
+
+		insertOrder(orderBook, 1, order.SideAsk, 100.0, 1.0)
+		insertOrder(orderBook, 2, order.SideBid, 100.0, 1.0)
+
+		insertOrder(orderBook, 3, order.SideAsk, 108.0, 1.0)
+		insertOrder(orderBook, 4, order.SideAsk, 115.0, 1.0)
+		stopOrder := &order.Order{
+			ID:        5,
+			Side:      order.SideBid,
+			Price:     decimal.NewFromFloat(109.0),
+			Quantity:  decimal.NewFromFloat(1.0),
+			StopPrice: decimal.NewFromFloat(105.0),
+			Trigger:   order.TriggerRisesTo,
+			ExpiresAt: fakeClock.Now().Add(10 * time.Second),
+		}
+		_, err := orderBook.InsertOrder(stopOrder)
+		s.NoError(err)
+
+		_, err = insertOrder(orderBook, 6, order.SideBid, 115.0, 2.0)
+		s.Equal(ErrCircuitBreakerTripped, err)
+		s.Equal(order.StateTriggered, stopOrder.State)
+
+		fakeClock.Add(20 * time.Second)
+		s.NoError(orderBook.SetTradingState(TradingStateContinuous))
+		s.Equal(order.StateExpired, stopOrder.State)
+		s.True(orderBook.Bids.Empty())
+		_, err = orderBook.GetOrder(5)
+		s.Equal(ErrOrderNotFound, err)
+	})
+
+	s.Run("fill or kill", func() {
+		orderBook := NewOrderBook("market", WithCircuitBreaker(CircuitBreaker{
+			Band: decimal.NewFromFloat(5.0),
+		}))
+
+		insertOrder(orderBook, 1, order.SideAsk, 100.0, 1.0)
+		insertOrder(orderBook, 2, order.SideBid, 100.0, 1.0)
+
+		insertOrder(orderBook, 3, order.SideAsk, 100.0, 1.0)
+		insertOrder(orderBook, 4, order.SideAsk, 120.0, 1.0)
+		fokOrder := &order.Order{
+			ID:         5,
+			Side:       order.SideBid,
+			Price:      decimal.NewFromFloat(120.0),
+			Quantity:   decimal.NewFromFloat(2.0),
+			FillOrKill: true,
+		}
+		trades, err := orderBook.InsertOrder(fokOrder)
+		s.NoError(err)
+		s.Empty(trades)
+		s.True(fokOrder.FilledQuantity.IsZero())
+		s.EqualValues(2, orderBook.Asks.Size())
+		s.Equal(TradingStateContinuous, orderBook.TradingState())
+	})
+
+	s.Run("reopen by auction", func() {
+		orderBook := NewOrderBook("market", WithCircuitBreaker(CircuitBreaker{
+			Band:            decimal.NewFromFloat(10.0),
+			ReopenByAuction: true,
+		}))
+
+		insertOrder(orderBook, 1, order.SideAsk, 100.0, 1.0)
+		insertOrder(orderBook, 2, order.SideBid, 100.0, 1.0)
+
+		insertOrder(orderBook, 3, order.SideAsk, 120.0, 1.0)
+		trades, err := insertOrder(orderBook, 4, order.SideBid, 120.0, 1.0)
+		s.Equal(ErrCircuitBreakerTripped, err)
+		s.Empty(trades)
+		s.Equal(TradingStatePreOpen, orderBook.TradingState())
+
+		_, err = insertOrder(orderBook, 5, order.SideBid, 120.0, 1.0)
+		s.NoError(err)
+
+		trades, err = orderBook.Uncross()
+		s.NoError(err)
+		s.Len(trades, 1)
+		s.True(decimal.NewFromFloat(120.0).Equal(orderBook.Price))
+		s.Equal(TradingStateContinuous, orderBook.TradingState())
+	})
+}
+
+func (s *suiteOrderBookTester) TestExecutionReports() {
+	type event struct {
+		id     uint64
+		state  order.State
+		reason string
+	}
+
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	fakeClock := clock.NewFakeClock(now)
+
+	events := []event{}
+	trades := []*trade.Trade{}
+	orderBook := NewOrderBook("market", WithClock(fakeClock), WithReporter(func(report *order.Report) {
+		if report.Trade != nil {
+			trades = append(trades, report.Trade)
+			return
+		}
+
+		events = append(events, event{report.Order.ID, report.Order.State, report.Reason})
+	}))
+
+	askOrder := &order.Order{
+		ID:       1,
+		Side:     order.SideAsk,
+		Price:    decimal.NewFromFloat(10.0),
+		Quantity: decimal.NewFromFloat(1.0),
+	}
+	orderBook.InsertOrder(askOrder)
+	_, err := orderBook.InsertOrder(askOrder)
+	s.Equal(ErrDuplicateOrder, err)
+
+	orderBook.InsertOrder(&order.Order{
+		ID:        2,
+		Side:      order.SideAsk,
+		Price:     decimal.NewFromFloat(8.0),
+		StopPrice: decimal.NewFromFloat(9.0),
+		Trigger:   order.TriggerFallsTo,
+		Quantity:  decimal.NewFromFloat(1.0),
+	})
+	orderBook.InsertOrder(&order.Order{
+		ID:        3,
+		Side:      order.SideAsk,
+		Price:     decimal.NewFromFloat(12.0),
+		Quantity:  decimal.NewFromFloat(1.0),
+		ExpiresAt: now.Add(time.Second),
+	})
+	orderBook.InsertOrder(&order.Order{
+		ID:                4,
+		Side:              order.SideBid,
+		Price:             decimal.NewFromFloat(10.0),
+		Quantity:          decimal.NewFromFloat(2.0),
+		ImmediateOrCancel: true,
+	})
+
+	fakeClock.Add(time.Second)
+	orderBook.InsertOrder(&order.Order{
+		ID:       5,
+		Side:     order.SideAsk,
+		Price:    decimal.NewFromFloat(9.0),
+		Quantity: decimal.NewFromFloat(1.0),
+	})
+	orderBook.InsertOrder(&order.Order{
+		ID:       6,
+		Side:     order.SideBid,
+		Price:    decimal.NewFromFloat(9.0),
+		Quantity: decimal.NewFromFloat(0.5),
+	})
+
+	s.Equal([]event{
+		{1, order.StatePending, ""},
+		{1, order.StateRejected, ErrDuplicateOrder.Error()},
+		{2, order.StatePending, ""},
+		{3, order.StatePending, ""},
+		{4, order.StatePending, ""},
+		{1, order.StateFilled, ""},
+		{4, order.StatePartiallyFilled, ""},
+		{4, order.StateCancelled, reasonNotFilled},
+		{3, order.StateExpired, ""},
+		{5, order.StatePending, ""},
+		{6, order.StatePending, ""},
+		{5, order.StatePartiallyFilled, ""},
+		{6, order.StateFilled, ""},
+		{2, order.StateTriggered, ""},
+	}, events)
+	s.Len(trades, 2)
+}
+
+func (s *suiteOrderBookTester) TestListOpenOrders() {
+	orderBook := NewOrderBook("market")
+
+	orders := []*order.Order{
+		{ID: 1, Side: order.SideAsk, Price: decimal.NewFromFloat(11.0), Quantity: decimal.NewFromFloat(1.0), OwnerID: 1},
+		{ID: 2, Side: order.SideBid, Price: decimal.NewFromFloat(9.0), Quantity: decimal.NewFromFloat(1.0), OwnerID: 2},
+		{ID: 3, Side: order.SideBid, Price: decimal.NewFromFloat(10.0), Quantity: decimal.NewFromFloat(2.0), OwnerID: 1},
+		{ID: 4, Side: order.SideAsk, Price: decimal.NewFromFloat(12.0), StopPrice: decimal.NewFromFloat(12.0), Quantity: decimal.NewFromFloat(1.0), OwnerID: 1},
+		{ID: 5, Side: order.SideAsk, Price: decimal.NewFromFloat(10.0), Quantity: decimal.NewFromFloat(1.0), OwnerID: 2},
+	}
+	for _, o := range orders {
+		_, err := orderBook.InsertOrder(o)
+		s.NoError(err)
+	}
+
+	o, err := orderBook.GetOrder(3)
+	s.NoError(err)
+	s.Equal(order.StatePartiallyFilled, o.State)
+	s.True(decimal.NewFromFloat(1.0).Equal(o.FilledQuantity))
+	s.True(decimal.NewFromFloat(1.0).Equal(o.PendingQuantity()))
+
+	o, err = orderBook.GetOrder(4)
+	s.NoError(err)
+	s.Equal(order.StatePending, o.State)
+
+	_, err = orderBook.GetOrder(5)
+	s.Equal(ErrOrderNotFound, err)
+
+	ids := func(orders []*order.Order) []uint64 {
+		result := []uint64{}
+		for _, o := range orders {
+			result = append(result, o.ID)
+		}
+		return result
+	}
+
+	s.Equal([]uint64{1, 2, 3, 4}, ids(orderBook.ListOpenOrders(OrderFilter{})))
+	s.Equal([]uint64{1, 4}, ids(orderBook.ListOpenOrders(OrderFilter{Sides: []order.Side{order.SideAsk}})))
+	s.Equal([]uint64{1, 3, 4}, ids(orderBook.ListOpenOrders(OrderFilter{OwnerID: 1})))
+	s.Equal([]uint64{1, 3}, ids(orderBook.ListOpenOrders(OrderFilter{OwnerID: 1, Limit: 2})))
+	afterID := uint64(3)
+	s.Equal([]uint64{4}, ids(orderBook.ListOpenOrders(OrderFilter{OwnerID: 1, AfterID: &afterID, Limit: 2})))
+
+	_, err = orderBook.InsertOrder(&order.Order{ID: 0, Side: order.SideBid, Price: decimal.NewFromFloat(8.0), Quantity: decimal.NewFromFloat(1.0), OwnerID: 2})
+	s.NoError(err)
+	s.Equal([]uint64{0, 2}, ids(orderBook.ListOpenOrders(OrderFilter{OwnerID: 2})))
+	s.Equal([]uint64{2}, ids(orderBook.ListOpenOrders(OrderFilter{OwnerID: 2, AfterID: new(uint64)})))
+}
+
+func (s *suiteOrderBookTester) TestMassCancel() {
+	orderBook := NewOrderBook("market")
+
+	orders := []*order.Order{
+		{ID: 1, Side: order.SideAsk, Price: decimal.NewFromFloat(11.0), Quantity: decimal.NewFromFloat(1.0), OwnerID: 1},
+		{ID: 2, Side: order.SideBid, Price: decimal.NewFromFloat(9.0), Quantity: decimal.NewFromFloat(1.0), OwnerID: 2},
+		{ID: 3, Side: order.SideBid, Price: decimal.NewFromFloat(8.0), Quantity: decimal.NewFromFloat(1.0), OwnerID: 1},
+		{ID: 4, Side: order.SideAsk, StopPrice: decimal.NewFromFloat(13.0), Quantity: decimal.NewFromFloat(1.0), OwnerID: 1},
+		{ID: 5, Side: order.SideAsk, Price: decimal.NewFromFloat(12.0), Quantity: decimal.NewFromFloat(1.0), OwnerID: 2},
+		{ID: 0, Side: order.SideBid, Price: decimal.NewFromFloat(7.0), Quantity: decimal.NewFromFloat(1.0), OwnerID: 2},
+	}
+	for _, o := range orders {
+		_, err := orderBook.InsertOrder(o)
+		s.NoError(err)
+	}
+
+	s.Equal([]uint64{3}, orderBook.MassCancel(OrderFilter{
+		OwnerID:  1,
+		MaxPrice: decimal.NewFromFloat(10.0),
+	}))
+	s.EqualValues(2, orderBook.Bids.Size())
+
+	s.Equal([]uint64{1, 4}, orderBook.MassCancel(OrderFilter{
+		Sides:    []order.Side{order.SideAsk},
+		OwnerID:  1,
+		MinPrice: decimal.NewFromFloat(10.0),
+	}))
+	s.True(orderBook.StopAsks.Empty())
+	s.EqualValues(1, orderBook.Asks.Size())
+	s.Equal(1, orderBook.depth.Asks.Size())
+
+	// paging fields never limit the orders cancelled.
+	afterID := uint64(0)
+	s.Equal([]uint64{0, 2, 5}, orderBook.MassCancel(OrderFilter{AfterID: &afterID, Limit: 1}))
+	s.True(orderBook.Bids.Empty())
+	s.True(orderBook.Asks.Empty())
+	s.Equal(0, orderBook.depth.Bids.Size())
+	s.Equal(0, orderBook.depth.Asks.Size())
+	s.Empty(orderBook.MassCancel(OrderFilter{}))
+}
+
+func (s *suiteOrderBookTester) TestDepthConsistency() {
+	orderBook := NewOrderBook("market", WithMatcher(ProRataMatcher{}))
+	random := rand.New(rand.NewSource(1))
+
+	for id := uint64(1); id <= 1000; id++ {
+		switch random.Intn(4) {
+		case 0:
+			orderBook.CancelOrder(&order.Order{ID: uint64(random.Int63n(int64(id)))})
+
+		case 1:
+			orderBook.AmendOrder(&order.Order{
+				ID:       uint64(random.Int63n(int64(id))),
+				Price:    decimal.New(random.Int63n(10)+1, 0),
+				Quantity: decimal.New(random.Int63n(10)+1, 0),
+			})
+
+		default:
+			side := order.SideBid
+			if random.Intn(2) == 0 {
+				side = order.SideAsk
+			}
+
+			orderBook.InsertOrder(&order.Order{
+				ID:              id,
+				Side:            side,
+				Price:           decimal.New(random.Int63n(10)+1, 0),
+				Quantity:        decimal.New(random.Int63n(10)+1, 0),
+				DisplayQuantity: decimal.New(random.Int63n(3), 0),
+				OwnerID:         uint64(random.Intn(3)),
+			})
+		}
+
+		s.Require().Empty(orderBook.CheckDepth(), "depth is inconsistent after order %d", id)
+	}
+
+	s.Equal(orderBook.Bids.Size()+orderBook.Asks.Size(), len(orderBook.ListOpenOrders(OrderFilter{})))
+}
+
+func (s *suiteOrderBookTester) TestOrderEvents() {
+	type levelOrder struct {
+		ID       uint64
+		Quantity string
+	}
+
+	levels := map[string][]levelOrder{}
+	sequence := uint64(0)
+
+	levelKey := func(side order.Side, price decimal.Decimal) string {
+		return string(side) + "@" + price.String()
+	}
+
+	indexOf := func(orders []levelOrder, id uint64) int {
+		for i, o := range orders {
+			if o.ID == id {
+				return i
+			}
+		}
+
+		s.FailNow("order not found", "anonymised order %d", id)
+		return -1
+	}
+
+	orderBook := NewOrderBook("market", WithMatcher(ProRataMatcher{}), WithOrderListener(func(event *OrderEvent) {
+		s.Require().Equal(sequence+1, event.Sequence)
+		sequence = event.Sequence
+
+		key := levelKey(event.Side, event.Price)
+		orders := levels[key]
+		switch event.Type {
+		case OrderEventAdd:
+			s.Require().True(event.Position >= 1 && event.Position <= len(orders)+1)
+			orders = append(orders, levelOrder{})
+			copy(orders[event.Position:], orders[event.Position-1:])
+			orders[event.Position-1] = levelOrder{ID: event.ID, Quantity: event.Quantity.String()}
+
+		case OrderEventModify, OrderEventExecute:
+			i := indexOf(orders, event.ID)
+			orders[i].Quantity = event.Quantity.String()
+			if event.Quantity.IsZero() {
+				orders = append(orders[:i], orders[i+1:]...)
+			}
+
+		case OrderEventDelete:
+			i := indexOf(orders, event.ID)
+			orders = append(orders[:i], orders[i+1:]...)
+		}
+
+		levels[key] = orders
+		if len(orders) == 0 {
+			delete(levels, key)
+		}
+	}))
+
+	random := rand.New(rand.NewSource(1))
+	for id := uint64(1); id <= 1000; id++ {
+		switch random.Intn(4) {
+		case 0:
+			orderBook.CancelOrder(&order.Order{ID: uint64(random.Int63n(int64(id)))})
+
+		case 1:
+			orderBook.AmendOrder(&order.Order{
+				ID:       uint64(random.Int63n(int64(id))),
+				Price:    decimal.New(random.Int63n(10)+1, 0),
+				Quantity: decimal.New(random.Int63n(10)+1, 0),
+			})
+
+		default:
+			side := order.SideBid
+			if random.Intn(2) == 0 {
+				side = order.SideAsk
+			}
+
+			orderBook.InsertOrder(&order.Order{
+				ID:                  id,
+				Side:                side,
+				Price:               decimal.New(random.Int63n(10)+1, 0),
+				Quantity:            decimal.New(random.Int63n(10)+1, 0),
+				DisplayQuantity:     decimal.New(random.Int63n(3), 0),
+				OwnerID:             uint64(random.Intn(3)),
+				SelfTradePrevention: order.SelfTradePreventionDecrementAndCancel,
+			})
+		}
+
+		snapshot := orderBook.GetSnapshot()
+		s.Require().Equal(sequence, snapshot.Sequence)
+
+		expected := map[string][]levelOrder{}
+		for _, restingOrder := range append(snapshot.Bids, snapshot.Asks...) {
+			key := levelKey(restingOrder.Side, restingOrder.Price)
+			s.Require().Equal(len(expected[key])+1, restingOrder.Position)
+			expected[key] = append(expected[key], levelOrder{ID: restingOrder.ID, Quantity: restingOrder.Quantity.String()})
+		}
+
+		s.Require().Equal(expected, levels, "order events are inconsistent after order %d", id)
+	}
+}
+
+func (s *suiteOrderBookTester) TestOrderEventsOfIcebergOrder() {
+	events := []*OrderEvent{}
+	orderBook := NewOrderBook("market", WithOrderListener(func(event *OrderEvent) {
+		events = append(events, event)
+	}))
+
+	orderBook.InsertOrder(&order.Order{
+		ID:              1,
+		Side:            order.SideAsk,
+		Price:           decimal.NewFromFloat(10.0),
+		Quantity:        decimal.NewFromFloat(5.0),
+		DisplayQuantity: decimal.NewFromFloat(2.0),
+	})
+	orderBook.InsertOrder(&order.Order{
+		ID:       2,
+		Side:     order.SideAsk,
+		Price:    decimal.NewFromFloat(10.0),
+		Quantity: decimal.NewFromFloat(1.0),
+	})
+	orderBook.InsertOrder(&order.Order{
+		ID:       3,
+		Side:     order.SideBid,
+		Price:    decimal.NewFromFloat(10.0),
+		Quantity: decimal.NewFromFloat(2.0),
+	})
+
+	s.Require().Len(events, 4)
+	s.Equal(OrderEventAdd, events[0].Type)
+	s.Equal("2", events[0].Quantity.String())
+	s.Equal(2, events[1].Position)
+
+	s.Equal(OrderEventExecute, events[2].Type)
+	s.Equal(events[0].ID, events[2].ID)
+	s.Equal("2", events[2].ExecutedQuantity.String())
+	s.True(events[2].Quantity.IsZero())
+
+	s.Equal(OrderEventAdd, events[3].Type)
+	s.NotEqual(events[0].ID, events[3].ID)
+	s.Equal("2", events[3].Quantity.String())
+	s.Equal(2, events[3].Position)
+
+	snapshot := orderBook.GetSnapshot()
+	s.Equal(uint64(4), snapshot.Sequence)
+	s.Len(snapshot.Asks, 2)
+	s.Equal(events[1].ID, snapshot.Asks[0].ID)
+	s.Equal(events[3].ID, snapshot.Asks[1].ID)
+	s.Equal(2, snapshot.Asks[1].Position)
+}
+
+func (s *suiteOrderBookTester) TestCancelOrder() {
+	orderBook := NewOrderBook("market")
+
+	bidOrder := &order.Order{
+		ID:       1,
+		Side:     order.SideBid,
+		Price:    decimal.NewFromFloat(10.0),
+		Quantity: decimal.NewFromFloat(30.0),
+	}
+
+	askOrder := &order.Order{
+		ID:       2,
+		Side:     order.SideAsk,
+		Price:    decimal.NewFromFloat(10.0),
+		Quantity: decimal.NewFromFloat(30.0),
+	}
+
+	orderBook.InsertOrder(bidOrder)
+	orderBook.InsertOrder(askOrder)
+
+	orderBook.CancelOrder(bidOrder)
+	s.Nil(orderBook.Bids.Right())
+	s.EqualValues(0, orderBook.Bids.Size())
+
+	orderBook.CancelOrder(askOrder)
+	s.Nil(orderBook.Asks.Right())
+	s.EqualValues(0, orderBook.Asks.Size())
+
+	orderBook.InsertOrder(bidOrder)
+	orderBook.CancelOrder(&order.Order{
+		ID: 1,
+	})
+	s.Nil(orderBook.Bids.Right())
+	s.EqualValues(0, orderBook.Bids.Size())
+
+	_, err := orderBook.CancelOrder(&order.Order{
+		ID: 1,
+	})
+	s.Equal(ErrOrderNotFound, err)
+
+	stopOrder := &order.Order{
+		ID:        3,
+		Side:      order.SideAsk,
+		Price:     decimal.NewFromFloat(9.0),
+		StopPrice: decimal.NewFromFloat(9.0),
+		Trigger:   order.TriggerFallsTo,
+		Quantity:  decimal.NewFromFloat(1.0),
+		GroupID:   3,
+	}
+	limitOrder := &order.Order{
+		ID:       4,
+		Side:     order.SideAsk,
+		Price:    decimal.NewFromFloat(11.0),
+		Quantity: decimal.NewFromFloat(1.0),
+		GroupID:  3,
+	}
+	_, err = orderBook.InsertOCOOrder(stopOrder, limitOrder)
+	s.NoError(err)
+
+	cancelledOrder, err := orderBook.CancelOrder(&order.Order{
+		ID: 3,
+	})
+	s.NoError(err)
+	s.Equal(uint64(3), cancelledOrder.ID)
+	s.Equal(order.StateCancelled, cancelledOrder.State)
+	s.True(orderBook.StopAsks.Empty())
+	s.True(orderBook.Asks.Empty())
+	s.Equal(0, orderBook.depth.Asks.Size())
+	s.Equal(0, orderBook.depth.Bids.Size())
+	s.Empty(orderBook.ListOpenOrders(OrderFilter{}))
 }
 
 func TestOrderBook(t *testing.T) {
@@ -0,0 +1,274 @@
+package orderbook
+
+import (
+	"github.com/draveness/oceanbook/api/protobuf-spec/oceanbookpb"
+	"github.com/draveness/oceanbook/pkg/order"
+	rbt "github.com/emirpasic/gods/trees/redblacktree"
+	"github.com/shopspring/decimal"
+)
+
+// OrderEventType is the change of resting order in the order-by-order feed.
+type OrderEventType string
+
+const (
+	// OrderEventAdd adds the order at its queue position in the price level.
+	OrderEventAdd OrderEventType = "add"
+
+	// OrderEventModify changes the visible quantity of the order, and the
+	// order keeps its queue position.
+	OrderEventModify OrderEventType = "modify"
+
+	// OrderEventDelete removes the order from orderbook.
+	OrderEventDelete OrderEventType = "delete"
+
+	// OrderEventExecute fills the visible quantity of the order, and the order
+	// is removed when no visible quantity is left.
+	OrderEventExecute OrderEventType = "execute"
+)
+
+var serializedOrderEventTypes = map[OrderEventType]oceanbookpb.OrderEvent_Type{
+	OrderEventAdd:     oceanbookpb.OrderEvent_ADD,
+	OrderEventModify:  oceanbookpb.OrderEvent_MODIFY,
+	OrderEventDelete:  oceanbookpb.OrderEvent_DELETE,
+	OrderEventExecute: oceanbookpb.OrderEvent_EXECUTE,
+}
+
+// OrderEvent is the change of a resting order, the order is identified by an
+// anonymised id and only its visible quantity is published.
+type OrderEvent struct {
+	Sequence uint64
+	Type     OrderEventType
+	ID       uint64
+	Side     order.Side
+	Price    decimal.Decimal
+
+	// Quantity is the visible quantity of the order after the event.
+	Quantity decimal.Decimal
+
+	// ExecutedQuantity is the quantity filled by the execute event.
+	ExecutedQuantity decimal.Decimal
+
+	// Position is the queue position of the added order in its price level,
+	// starting from 1.
+	Position int
+}
+
+// Serialize returns a protobuf encoded order event.
+func (e *OrderEvent) Serialize() *oceanbookpb.OrderEvent {
+	return &oceanbookpb.OrderEvent{
+		Sequence:         e.Sequence,
+		Type:             serializedOrderEventTypes[e.Type],
+		OrderId:          e.ID,
+		Side:             e.Side.Serialize(),
+		Price:            e.Price.String(),
+		Quantity:         e.Quantity.String(),
+		ExecutedQuantity: e.ExecutedQuantity.String(),
+		Position:         uint32(e.Position),
+	}
+}
+
+// OrderListener receives order events in sequence order, and it is called
+// while the orderbook is locked.
+type OrderListener func(event *OrderEvent)
+
+// RestingOrder is an anonymised order resting in orderbook.
+type RestingOrder struct {
+	ID       uint64
+	Side     order.Side
+	Price    decimal.Decimal
+	Quantity decimal.Decimal
+	Position int
+}
+
+// Serialize returns a protobuf encoded resting order.
+func (o *RestingOrder) Serialize() *oceanbookpb.RestingOrder {
+	return &oceanbookpb.RestingOrder{
+		OrderId:  o.ID,
+		Price:    o.Price.String(),
+		Quantity: o.Quantity.String(),
+		Position: uint32(o.Position),
+	}
+}
+
+// OrderBookSnapshot is the resting orders of orderbook from the best one,
+// taken after the order event with the sequence number.
+type OrderBookSnapshot struct {
+	Symbol   string
+	Sequence uint64
+	Bids     []*RestingOrder
+	Asks     []*RestingOrder
+}
+
+// Serialize returns a protobuf encoded orderbook snapshot.
+func (s *OrderBookSnapshot) Serialize() *oceanbookpb.OrderBookSnapshot {
+	serialized := &oceanbookpb.OrderBookSnapshot{
+		Symbol:   s.Symbol,
+		Sequence: s.Sequence,
+		Bids:     make([]*oceanbookpb.RestingOrder, len(s.Bids)),
+		Asks:     make([]*oceanbookpb.RestingOrder, len(s.Asks)),
+	}
+
+	for i, o := range s.Bids {
+		serialized.Bids[i] = o.Serialize()
+	}
+
+	for i, o := range s.Asks {
+		serialized.Asks[i] = o.Serialize()
+	}
+
+	return serialized
+}
+
+// GetSnapshot returns the anonymised resting orders of orderbook with their
+// queue positions.
+func (od *OrderBook) GetSnapshot() *OrderBookSnapshot {
+	od.RLock()
+	defer od.RUnlock()
+
+	return &OrderBookSnapshot{
+		Symbol:   od.Symbol,
+		Sequence: od.orderSequence,
+		Bids:     od.restingOrders(od.Bids),
+		Asks:     od.restingOrders(od.Asks),
+	}
+}
+
+// restingOrders returns anonymised orders in books by priority.
+func (od *OrderBook) restingOrders(books *rbt.Tree) []*RestingOrder {
+	orders := []*RestingOrder{}
+
+	it := books.Iterator()
+	for it.End(); it.Prev(); {
+		o := it.Value().(*order.Order)
+
+		position := 1
+		if len(orders) > 0 && orders[len(orders)-1].Price.Equal(o.Price) {
+			position = orders[len(orders)-1].Position + 1
+		}
+
+		orders = append(orders, &RestingOrder{
+			ID:       od.publicIDs[o.ID],
+			Side:     o.Side,
+			Price:    o.Price,
+			Quantity: o.VisibleQuantity(),
+			Position: position,
+		})
+	}
+
+	return orders
+}
+
+// publishAdd assigns an anonymised id to the order resting in books, and
+// publishes it with its queue position.
+func (od *OrderBook) publishAdd(books *rbt.Tree, o *order.Order) {
+	od.lastPublicID++
+	od.publicIDs[o.ID] = od.lastPublicID
+
+	event := &OrderEvent{
+		Type:     OrderEventAdd,
+		ID:       od.lastPublicID,
+		Side:     o.Side,
+		Price:    o.Price,
+		Quantity: o.VisibleQuantity(),
+	}
+	if od.orderListener != nil {
+		event.Position = queuePosition(books, o)
+	}
+
+	od.publishOrderEvent(event)
+}
+
+// publishModify publishes the change of visible quantity of the resting
+// order, hidden quantity of iceberg order is never published.
+func (od *OrderBook) publishModify(o *order.Order, visibleQuantity decimal.Decimal) {
+	if o.VisibleQuantity().Equal(visibleQuantity) {
+		return
+	}
+
+	od.publishOrderEvent(&OrderEvent{
+		Type:     OrderEventModify,
+		ID:       od.publicIDs[o.ID],
+		Side:     o.Side,
+		Price:    o.Price,
+		Quantity: o.VisibleQuantity(),
+	})
+}
+
+// publishExecute publishes the fill of the resting order, and releases its
+// anonymised id when no visible quantity is left.
+func (od *OrderBook) publishExecute(o *order.Order, quantity decimal.Decimal) {
+	event := &OrderEvent{
+		Type:             OrderEventExecute,
+		ID:               od.publicIDs[o.ID],
+		Side:             o.Side,
+		Price:            o.Price,
+		Quantity:         o.VisibleQuantity(),
+		ExecutedQuantity: quantity,
+	}
+	if event.Quantity.IsZero() {
+		delete(od.publicIDs, o.ID)
+	}
+
+	od.publishOrderEvent(event)
+}
+
+// publishDelete publishes the removal of the resting order, and releases its
+// anonymised id.
+func (od *OrderBook) publishDelete(o *order.Order) {
+	event := &OrderEvent{
+		Type:     OrderEventDelete,
+		ID:       od.publicIDs[o.ID],
+		Side:     o.Side,
+		Price:    o.Price,
+		Quantity: decimal.Zero,
+	}
+	delete(od.publicIDs, o.ID)
+
+	od.publishOrderEvent(event)
+}
+
+// publishOrderEvent assigns the next sequence number of orderbook to the
+// event and publishes it.
+func (od *OrderBook) publishOrderEvent(event *OrderEvent) {
+	od.orderSequence++
+	event.Sequence = od.orderSequence
+
+	if od.orderListener != nil {
+		od.orderListener(event)
+	}
+}
+
+// queuePosition returns the position of the order in its price level,
+// starting from 1 for the order with the highest priority.
+func queuePosition(books *rbt.Tree, o *order.Order) int {
+	node, found := books.Floor(o.Key())
+	if !found {
+		return 0
+	}
+
+	position := 1
+	for node = nextNode(node); node != nil && node.Value.(*order.Order).Price.Equal(o.Price); node = nextNode(node) {
+		position++
+	}
+
+	return position
+}
+
+// nextNode returns the in-order successor of the node, which is the order
+// with higher priority in books.
+func nextNode(node *rbt.Node) *rbt.Node {
+	if node.Right != nil {
+		node = node.Right
+		for node.Left != nil {
+			node = node.Left
+		}
+
+		return node
+	}
+
+	for node.Parent != nil && node == node.Parent.Right {
+		node = node.Parent
+	}
+
+	return node.Parent
+}
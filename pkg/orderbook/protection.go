@@ -0,0 +1,77 @@
+package orderbook
+
+import (
+	"github.com/draveness/oceanbook/pkg/order"
+	rbt "github.com/emirpasic/gods/trees/redblacktree"
+	"github.com/shopspring/decimal"
+)
+
+// ProtectionReference is the price which market protection deviation is
+// measured from.
+type ProtectionReference string
+
+const (
+	// ProtectionReferenceLastPrice measures deviation from the last traded
+	// price, and falls back to the best price before the first trade.
+	ProtectionReferenceLastPrice ProtectionReference = "last_price"
+
+	// ProtectionReferenceBestPrice measures deviation from the best opposite
+	// price when the market order arrives.
+	ProtectionReferenceBestPrice ProtectionReference = "best_price"
+)
+
+// MarketProtection bounds the prices market orders trade at. Market order
+// stops matching once the maker price deviates from the reference price by
+// more than the percentage, and its remainder is cancelled or rests as a
+// limit order at the protection price.
+type MarketProtection struct {
+	Deviation     decimal.Decimal
+	Reference     ProtectionReference
+	RestRemainder bool
+}
+
+// Enabled returns true when market orders are protected.
+func (p MarketProtection) Enabled() bool {
+	return p.Deviation.IsPositive()
+}
+
+// protectionPrice returns the worst price the market order is allowed to
+// trade at, zero means the order is not bounded.
+func (od *OrderBook) protectionPrice(side order.Side, makerBooks *rbt.Tree) decimal.Decimal {
+	if !od.protection.Enabled() {
+		return decimal.Zero
+	}
+
+	reference := decimal.Zero
+	if od.protection.Reference != ProtectionReferenceBestPrice {
+		reference = od.Price
+	}
+
+	if !reference.IsPositive() {
+		best := makerBooks.Right()
+		if best == nil {
+			return decimal.Zero
+		}
+
+		reference = best.Value.(*order.Order).Price
+	}
+
+	hundred := decimal.New(100, 0)
+
+	var price decimal.Decimal
+	switch side {
+	case order.SideBid:
+		price = reference.Mul(hundred.Add(od.protection.Deviation)).Div(hundred)
+		if od.Spec.TickSize.IsPositive() {
+			price = price.Div(od.Spec.TickSize).Floor().Mul(od.Spec.TickSize)
+		}
+
+	case order.SideAsk:
+		price = reference.Mul(hundred.Sub(od.protection.Deviation)).Div(hundred)
+		if od.Spec.TickSize.IsPositive() {
+			price = price.Div(od.Spec.TickSize).Ceil().Mul(od.Spec.TickSize)
+		}
+	}
+
+	return decimal.Max(price, decimal.Zero)
+}
@@ -0,0 +1,162 @@
+package orderbook
+
+import (
+	"sort"
+
+	"github.com/draveness/oceanbook/pkg/order"
+	"github.com/shopspring/decimal"
+	log "github.com/sirupsen/logrus"
+)
+
+// OrderFilter selects open orders in the orderbook, zero value of each field
+// matches all orders.
+type OrderFilter struct {
+	Sides   []order.Side
+	OwnerID uint64
+
+	// MinPrice and MaxPrice bound the limit price of orders, or the stop price
+	// of stop market orders.
+	MinPrice decimal.Decimal
+	MaxPrice decimal.Decimal
+
+	// AfterID skips orders whose id is not greater than it, and it is the
+	// last id of the previous page. It is nil for the first page, so that
+	// order with zero id is not skipped.
+	AfterID *uint64
+
+	// Limit is the maximum number of orders returned.
+	Limit int
+}
+
+// matches returns true when the order is selected by filter.
+func (filter OrderFilter) matches(o *order.Order) bool {
+	if filter.AfterID != nil && o.ID <= *filter.AfterID {
+		return false
+	}
+
+	if filter.OwnerID != 0 && o.OwnerID != filter.OwnerID {
+		return false
+	}
+
+	price := o.Price
+	if price.IsZero() {
+		price = o.StopPrice
+	}
+
+	if filter.MinPrice.IsPositive() && price.LessThan(filter.MinPrice) {
+		return false
+	}
+
+	if filter.MaxPrice.IsPositive() && price.GreaterThan(filter.MaxPrice) {
+		return false
+	}
+
+	if len(filter.Sides) == 0 {
+		return true
+	}
+
+	for _, side := range filter.Sides {
+		if o.Side == side {
+			return true
+		}
+	}
+
+	return false
+}
+
+// GetOrder returns the snapshot of resting, stop or triggered but pending
+// order with specified id.
+func (od *OrderBook) GetOrder(id uint64) (*order.Order, error) {
+	od.RLock()
+	defer od.RUnlock()
+
+	o, ok := od.openOrder(id)
+	if !ok {
+		return nil, ErrOrderNotFound
+	}
+
+	snapshot := *o
+
+	return &snapshot, nil
+}
+
+// ListOpenOrders returns snapshots of resting, stop and triggered but pending
+// orders selected by the filter, sorted by id.
+func (od *OrderBook) ListOpenOrders(filter OrderFilter) []*order.Order {
+	od.RLock()
+	defer od.RUnlock()
+
+	orders := od.openOrders(filter)
+	for i, o := range orders {
+		snapshot := *o
+		orders[i] = &snapshot
+	}
+
+	return orders
+}
+
+// MassCancel removes resting, stop and triggered but pending orders selected by
+// the filter together with their linked orders, and returns ids of cancelled
+// orders. Paging fields of the filter are ignored.
+func (od *OrderBook) MassCancel(filter OrderFilter) []uint64 {
+	od.Lock()
+	defer od.Unlock()
+
+	// all selected orders are cancelled at once.
+	filter.AfterID = nil
+	filter.Limit = 0
+
+	cancelledIDs := []uint64{}
+	for _, o := range od.openOrders(filter) {
+		// linked orders of the orders cancelled before are already removed.
+		if !od.removeOrder(o) {
+			continue
+		}
+
+		od.reportState(o, order.StateCancelled, "")
+		cancelledIDs = append(cancelledIDs, o.ID)
+
+		for _, linkedOrder := range od.cancelLinkedOrders(o) {
+			cancelledIDs = append(cancelledIDs, linkedOrder.ID)
+		}
+	}
+
+	log.Infof("[oceanbook.orderbook] %d orders of orderbook %s cancelled", len(cancelledIDs), od.Symbol)
+
+	return cancelledIDs
+}
+
+// openOrder returns the resting, stop or triggered but pending order with
+// specified id.
+func (od *OrderBook) openOrder(id uint64) (*order.Order, bool) {
+	for _, openOrders := range []map[uint64]*order.Order{od.cancelOrdersQueue, od.stopOrders, od.pendingOrders} {
+		if o, ok := openOrders[id]; ok {
+			return o, true
+		}
+	}
+
+	return nil, false
+}
+
+// openOrders returns resting, stop and triggered but pending orders selected
+// by the filter, sorted by id.
+func (od *OrderBook) openOrders(filter OrderFilter) []*order.Order {
+	orders := []*order.Order{}
+	for _, openOrders := range []map[uint64]*order.Order{od.cancelOrdersQueue, od.stopOrders, od.pendingOrders} {
+		for _, o := range openOrders {
+			if filter.matches(o) {
+				orders = append(orders, o)
+			}
+		}
+	}
+
+	sort.Slice(orders, func(i, j int) bool {
+		return orders[i].ID < orders[j].ID
+	})
+
+	if filter.Limit > 0 && len(orders) > filter.Limit {
+		orders = orders[:filter.Limit]
+	}
+
+	return orders
+}
@@ -0,0 +1,83 @@
+package orderbook
+
+import (
+	"github.com/draveness/oceanbook/pkg/order"
+	"github.com/draveness/oceanbook/pkg/trade"
+)
+
+// Reporter receives execution reports in the order that they happen, and it
+// is called while the orderbook is locked.
+type Reporter func(report *order.Report)
+
+const (
+	// reasonNotFilled cancels the remainder of immediate or cancel, fill or
+	// kill and market orders.
+	reasonNotFilled = "order not filled immediately"
+
+	// reasonSelfTrade cancels orders by self trade prevention.
+	reasonSelfTrade = "self trade prevention"
+
+	// reasonLinkedOrder cancels orders by the filled, triggered or cancelled
+	// order in the same one-cancels-other group.
+	reasonLinkedOrder = "linked order filled, triggered or cancelled"
+
+	// reasonProtectionRested rests the remainder of market order as a limit
+	// order at the protection price.
+	reasonProtectionRested = "market order remainder rests at protection price"
+)
+
+// reportState transits the order to the state and reports it.
+func (od *OrderBook) reportState(o *order.Order, state order.State, reason string) {
+	o.State = state
+
+	if od.reporter == nil {
+		return
+	}
+
+	snapshot := *o
+	od.reporter(&order.Report{
+		Order:  &snapshot,
+		Reason: reason,
+	})
+}
+
+// stampTrade numbers the new trade and sets its execution time.
+func (od *OrderBook) stampTrade(t *trade.Trade) {
+	od.lastTradeID++
+
+	t.ID = od.lastTradeID
+	t.CreatedAt = od.clock.Now()
+}
+
+// reportTrade reports the trade and the fills of its maker and taker.
+func (od *OrderBook) reportTrade(t *trade.Trade, maker, taker *order.Order) {
+	if od.reporter != nil {
+		od.reporter(&order.Report{Trade: t})
+	}
+
+	for _, o := range []*order.Order{maker, taker} {
+		state := order.StatePartiallyFilled
+		if o.Filled() {
+			state = order.StateFilled
+		}
+
+		od.reportState(o, state, "")
+	}
+}
+
+// accept reports the new order accepted by orderbook, triggered and amended
+// orders are accepted before.
+func (od *OrderBook) accept(o *order.Order) {
+	if o.State != "" {
+		return
+	}
+
+	od.reportState(o, order.StatePending, "")
+}
+
+// reject reports the order rejected by the error and returns the error.
+func (od *OrderBook) reject(o *order.Order, err error) error {
+	od.reportState(o, order.StateRejected, err.Error())
+
+	return err
+}
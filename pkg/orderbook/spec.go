@@ -0,0 +1,130 @@
+package orderbook
+
+import (
+	"errors"
+
+	"github.com/draveness/oceanbook/pkg/order"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	// ErrInvalidPriceTick returns when order price is not a multiple of tick size.
+	ErrInvalidPriceTick = errors.New("price is not a multiple of tick size")
+
+	// ErrInvalidPricePrecision returns when order price has more decimal places than allowed.
+	ErrInvalidPricePrecision = errors.New("price exceeds price precision")
+
+	// ErrInvalidQuantityStep returns when order quantity is not a multiple of lot size.
+	ErrInvalidQuantityStep = errors.New("quantity is not a multiple of lot size")
+
+	// ErrInvalidQuantityPrecision returns when order quantity has more decimal places than allowed.
+	ErrInvalidQuantityPrecision = errors.New("quantity exceeds quantity precision")
+
+	// ErrQuantityTooSmall returns when order quantity is less than minimum quantity.
+	ErrQuantityTooSmall = errors.New("quantity is less than minimum quantity")
+
+	// ErrQuantityTooLarge returns when order quantity is greater than maximum quantity.
+	ErrQuantityTooLarge = errors.New("quantity is greater than maximum quantity")
+
+	// ErrNotionalTooSmall returns when order notional is less than minimum notional.
+	ErrNotionalTooSmall = errors.New("notional is less than minimum notional")
+)
+
+// MarketSpec is the trading rules of a market, zero value of each rule means
+// the rule is not enforced.
+type MarketSpec struct {
+	// TickSize is the minimum price movement.
+	TickSize decimal.Decimal
+
+	// LotSize is the minimum quantity movement.
+	LotSize decimal.Decimal
+
+	// PricePrecision is the maximum decimal places of price.
+	PricePrecision int32
+
+	// QuantityPrecision is the maximum decimal places of quantity.
+	QuantityPrecision int32
+
+	MinQuantity decimal.Decimal
+	MaxQuantity decimal.Decimal
+
+	// MinNotional is the minimum value of price times quantity. Market orders
+	// are checked by their stop price or quote quantity when there is one.
+	MinNotional decimal.Decimal
+}
+
+// Validate returns error when the order breaks trading rules of the market.
+func (spec MarketSpec) Validate(o *order.Order) error {
+	for _, price := range []decimal.Decimal{o.Price, o.StopPrice} {
+		if err := spec.validatePrice(price); err != nil {
+			return err
+		}
+	}
+
+	if !o.IsQuoteQuantity() {
+		if err := spec.validateQuantity(o.Quantity); err != nil {
+			return err
+		}
+	}
+
+	if !spec.MinNotional.IsPositive() {
+		return nil
+	}
+
+	var notional decimal.Decimal
+	switch {
+	case o.IsQuoteQuantity():
+		notional = o.QuoteQuantity
+
+	case o.IsLimit():
+		notional = o.Price.Mul(o.Quantity)
+
+	case o.IsStop():
+		notional = o.StopPrice.Mul(o.Quantity)
+
+	default:
+		return nil
+	}
+
+	if notional.LessThan(spec.MinNotional) {
+		return ErrNotionalTooSmall
+	}
+
+	return nil
+}
+
+func (spec MarketSpec) validatePrice(price decimal.Decimal) error {
+	if price.IsZero() {
+		return nil
+	}
+
+	if spec.PricePrecision > 0 && !price.Equal(price.Truncate(spec.PricePrecision)) {
+		return ErrInvalidPricePrecision
+	}
+
+	if spec.TickSize.IsPositive() && !price.Mod(spec.TickSize).IsZero() {
+		return ErrInvalidPriceTick
+	}
+
+	return nil
+}
+
+func (spec MarketSpec) validateQuantity(quantity decimal.Decimal) error {
+	if spec.QuantityPrecision > 0 && !quantity.Equal(quantity.Truncate(spec.QuantityPrecision)) {
+		return ErrInvalidQuantityPrecision
+	}
+
+	if spec.LotSize.IsPositive() && !quantity.Mod(spec.LotSize).IsZero() {
+		return ErrInvalidQuantityStep
+	}
+
+	if spec.MinQuantity.IsPositive() && quantity.LessThan(spec.MinQuantity) {
+		return ErrQuantityTooSmall
+	}
+
+	if spec.MaxQuantity.IsPositive() && quantity.GreaterThan(spec.MaxQuantity) {
+		return ErrQuantityTooLarge
+	}
+
+	return nil
+}
@@ -0,0 +1,60 @@
+package orderbook
+
+import (
+	"testing"
+
+	"github.com/draveness/oceanbook/pkg/order"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarketSpecValidate(t *testing.T) {
+	spec := MarketSpec{
+		TickSize:          decimal.NewFromFloat(0.05),
+		LotSize:           decimal.NewFromFloat(0.001),
+		PricePrecision:    2,
+		QuantityPrecision: 3,
+		MinQuantity:       decimal.NewFromFloat(0.01),
+		MaxQuantity:       decimal.NewFromFloat(100.0),
+		MinNotional:       decimal.NewFromFloat(10.0),
+	}
+
+	newOrder := func(price, quantity string) *order.Order {
+		return &order.Order{
+			ID:       1,
+			Side:     order.SideBid,
+			Price:    decimal.RequireFromString(price),
+			Quantity: decimal.RequireFromString(quantity),
+		}
+	}
+
+	tests := []struct {
+		name  string
+		order *order.Order
+		err   error
+	}{
+		{"valid", newOrder("100.05", "1.5"), nil},
+		{"price precision", newOrder("100.001", "1.5"), ErrInvalidPricePrecision},
+		{"price tick", newOrder("100.01", "1.5"), ErrInvalidPriceTick},
+		{"quantity precision", newOrder("100.05", "1.0005"), ErrInvalidQuantityPrecision},
+		{"quantity too small", newOrder("100.05", "0.005"), ErrQuantityTooSmall},
+		{"quantity too large", newOrder("100.05", "100.001"), ErrQuantityTooLarge},
+		{"notional too small", newOrder("100.05", "0.09"), ErrNotionalTooSmall},
+		{"market order", newOrder("0", "0.09"), nil},
+		{"stop price tick", &order.Order{
+			Side:      order.SideBid,
+			Quantity:  decimal.NewFromFloat(1.0),
+			StopPrice: decimal.NewFromFloat(100.03),
+		}, ErrInvalidPriceTick},
+		{"quote quantity notional", &order.Order{
+			Side:          order.SideBid,
+			QuoteQuantity: decimal.NewFromFloat(5.0),
+		}, ErrNotionalTooSmall},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.err, spec.Validate(test.order), test.name)
+	}
+
+	assert.Equal(t, ErrInvalidQuantityStep, MarketSpec{LotSize: decimal.NewFromFloat(0.5)}.Validate(newOrder("1", "1.2")))
+}
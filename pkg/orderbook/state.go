@@ -0,0 +1,149 @@
+package orderbook
+
+import (
+	"errors"
+
+	"github.com/draveness/oceanbook/pkg/order"
+	log "github.com/sirupsen/logrus"
+)
+
+var (
+	// ErrInvalidTradingState returns when the trading state is unknown.
+	ErrInvalidTradingState = errors.New("invalid trading state")
+
+	// ErrInsertNotAllowed returns when the trading state forbids inserting
+	// the order.
+	ErrInsertNotAllowed = errors.New("insert not allowed in current trading state")
+
+	// ErrAmendNotAllowed returns when the trading state forbids amending the
+	// order.
+	ErrAmendNotAllowed = errors.New("amend not allowed in current trading state")
+
+	// ErrOrderBookCrossed returns when resuming continuous trading while the
+	// best bid is not lower than the best ask, the orderbook should be
+	// uncrossed by an auction first.
+	ErrOrderBookCrossed = errors.New("orderbook is crossed")
+)
+
+// TradingState is the trading session state of orderbook, which decides the
+// operations allowed on the orderbook. Orders can be cancelled in all states.
+type TradingState string
+
+const (
+	// TradingStateContinuous allows all orders and matches them continuously.
+	TradingStateContinuous TradingState = "continuous"
+
+	// TradingStatePreOpen is the call auction, limit and stop orders
+	// accumulate without matching until uncross.
+	TradingStatePreOpen TradingState = "pre_open"
+
+	// TradingStatePostOnly only allows post only orders which add liquidity.
+	TradingStatePostOnly TradingState = "post_only"
+
+	// TradingStateCancelOnly only allows cancelling orders.
+	TradingStateCancelOnly TradingState = "cancel_only"
+
+	// TradingStateHalted suspends trading during incidents or volatility
+	// interruptions, orders can only be cancelled.
+	TradingStateHalted TradingState = "halted"
+
+	// TradingStateClosed is the state outside trading sessions, orders can
+	// only be cancelled.
+	TradingStateClosed TradingState = "closed"
+)
+
+// TradingState returns the trading state of orderbook.
+func (od *OrderBook) TradingState() TradingState {
+	od.RLock()
+	defer od.RUnlock()
+
+	return od.state
+}
+
+// SetTradingState transits the orderbook to the trading state. Orderbook in
+// call auction should be uncrossed before resuming continuous trading, and
+// stop orders triggered while trading was suspended are inserted when it
+// resumes.
+func (od *OrderBook) SetTradingState(state TradingState) error {
+	od.Lock()
+	defer od.Unlock()
+
+	switch state {
+	case TradingStateContinuous:
+		if od.crossed() {
+			return ErrOrderBookCrossed
+		}
+
+	case TradingStatePreOpen, TradingStatePostOnly, TradingStateCancelOnly, TradingStateHalted, TradingStateClosed:
+
+	default:
+		return ErrInvalidTradingState
+	}
+
+	log.Infof("[oceanbook.orderbook] orderbook %s transits from %s to %s", od.Symbol, od.state, state)
+
+	if state == TradingStateContinuous {
+		od.resetReferencePrice()
+	}
+
+	od.state = state
+
+	if state == TradingStateContinuous {
+		od.insertPendingOrders()
+	}
+
+	return nil
+}
+
+// allowInsert returns error when the trading state forbids inserting the order.
+func (od *OrderBook) allowInsert(o *order.Order) error {
+	switch od.state {
+	case TradingStateContinuous:
+		return nil
+
+	case TradingStatePreOpen:
+		if o.ImmediateOrCancel || o.FillOrKill || (o.IsMarket() && !o.IsStop() && !o.IsTrailing()) {
+			return ErrInvalidAuctionOrder
+		}
+
+		return nil
+
+	case TradingStatePostOnly:
+		if !o.PostOnly {
+			return ErrInsertNotAllowed
+		}
+
+		return nil
+
+	default:
+		return ErrInsertNotAllowed
+	}
+}
+
+// allowAmend returns error when the trading state forbids amending the order.
+func (od *OrderBook) allowAmend(o *order.Order) error {
+	switch od.state {
+	case TradingStateContinuous, TradingStatePreOpen:
+		return nil
+
+	case TradingStatePostOnly:
+		if !o.PostOnly {
+			return ErrAmendNotAllowed
+		}
+
+		return nil
+
+	default:
+		return ErrAmendNotAllowed
+	}
+}
+
+// crossed returns true when the best bid is not lower than the best ask.
+func (od *OrderBook) crossed() bool {
+	best, worst := od.Bids.Right(), od.Asks.Right()
+	if best == nil || worst == nil {
+		return false
+	}
+
+	return best.Value.(*order.Order).Price.GreaterThanOrEqual(worst.Value.(*order.Order).Price)
+}
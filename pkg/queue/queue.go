@@ -42,6 +42,19 @@ func (oq *OrderQueue) Pop() *order.Order {
 	return o
 }
 
+// Remove removes the order with specified id from the order queue, and
+// returns false when the order is not found.
+func (oq *OrderQueue) Remove(id uint64) bool {
+	for i, o := range oq.values {
+		if o.ID == id {
+			oq.values = append(oq.values[:i], oq.values[i+1:]...)
+			return true
+		}
+	}
+
+	return false
+}
+
 // Clear removes all orders.
 func (oq *OrderQueue) Clear() {
 	oq.values = make([]*order.Order, 0, oq.size)
@@ -32,6 +32,22 @@ func (s *OrderQueueTestSuite) TestOrderQueue() {
 	}
 }
 
+func (s *OrderQueueTestSuite) TestRemove() {
+	orderQueue := NewOrderQueue(5)
+
+	for i := uint64(0); i < 3; i++ {
+		orderQueue.Push(&order.Order{
+			ID: i,
+		})
+	}
+
+	s.True(orderQueue.Remove(1))
+	s.False(orderQueue.Remove(1))
+	s.Equal(int64(2), orderQueue.Size())
+	s.Equal(&order.Order{ID: 0}, orderQueue.Pop())
+	s.Equal(&order.Order{ID: 2}, orderQueue.Pop())
+}
+
 func TestOrderQueue(t *testing.T) {
 	suite.Run(t, new(OrderQueueTestSuite))
 }
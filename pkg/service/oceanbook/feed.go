@@ -0,0 +1,94 @@
+package oceanbook
+
+import (
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// feed broadcasts market data events of an orderbook to its subscribers.
+type feed struct {
+	sync.Mutex
+	subscribers map[*subscriber]struct{}
+}
+
+// slowSubscriberPolicy decides how feed handles the subscriber whose buffer
+// is full.
+type slowSubscriberPolicy int
+
+const (
+	// disconnectSlowSubscriber removes the slow subscriber from feed.
+	disconnectSlowSubscriber slowSubscriberPolicy = iota
+
+	// dropSlowSubscriberEvents drops events until the slow subscriber catches
+	// up with feed.
+	dropSlowSubscriberEvents
+)
+
+// subscriber receives events from feed, its events channel is closed when it
+// falls behind and is disconnected.
+type subscriber struct {
+	events chan interface{}
+	policy slowSubscriberPolicy
+
+	// dropped is the number of events dropped for the subscriber.
+	dropped uint64
+}
+
+func newFeed() *feed {
+	return &feed{
+		subscribers: map[*subscriber]struct{}{},
+	}
+}
+
+// subscribe adds a subscriber which buffers no more than size events, and
+// the policy applies when its buffer is full.
+func (f *feed) subscribe(size int, policy slowSubscriberPolicy) *subscriber {
+	f.Lock()
+	defer f.Unlock()
+
+	sub := &subscriber{
+		events: make(chan interface{}, size),
+		policy: policy,
+	}
+	f.subscribers[sub] = struct{}{}
+
+	return sub
+}
+
+// unsubscribe removes the subscriber from feed.
+func (f *feed) unsubscribe(sub *subscriber) {
+	f.Lock()
+	defer f.Unlock()
+
+	if _, ok := f.subscribers[sub]; !ok {
+		return
+	}
+
+	delete(f.subscribers, sub)
+	close(sub.events)
+}
+
+// publish sends the event to subscribers without blocking, subscribers whose
+// buffer is full are handled by their policies.
+func (f *feed) publish(event interface{}) {
+	f.Lock()
+	defer f.Unlock()
+
+	for sub := range f.subscribers {
+		select {
+		case sub.events <- event:
+		default:
+			if sub.policy == dropSlowSubscriberEvents {
+				sub.dropped++
+				log.Debugf("[oceanbook.feed] drop event of slow subscriber, %d events dropped", sub.dropped)
+				continue
+			}
+
+			log.Warnf("[oceanbook.feed] disconnect slow subscriber with %d buffered events", len(sub.events))
+
+			delete(f.subscribers, sub)
+			close(sub.events)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package oceanbook
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFeed(t *testing.T) {
+	f := newFeed()
+
+	fast := f.subscribe(2, disconnectSlowSubscriber)
+	slow := f.subscribe(1, disconnectSlowSubscriber)
+	dropping := f.subscribe(1, dropSlowSubscriberEvents)
+
+	f.publish(1)
+	f.publish(2)
+
+	assert.Equal(t, 1, <-slow.events)
+	_, ok := <-slow.events
+	assert.False(t, ok)
+	f.unsubscribe(slow)
+
+	assert.Equal(t, 1, <-fast.events)
+	assert.Equal(t, 2, <-fast.events)
+
+	f.unsubscribe(fast)
+	_, ok = <-fast.events
+	assert.False(t, ok)
+
+	assert.Equal(t, 1, <-dropping.events)
+	assert.Equal(t, uint64(1), dropping.dropped)
+
+	f.publish(3)
+	assert.Equal(t, 3, <-dropping.events)
+
+	f.unsubscribe(dropping)
+	assert.Empty(t, f.subscribers)
+}
@@ -0,0 +1,198 @@
+package oceanbook
+
+import (
+	"sync"
+
+	"github.com/draveness/oceanbook/api/protobuf-spec/oceanbookpb"
+	"github.com/draveness/oceanbook/pkg/order"
+)
+
+// reportCollector collects execution reports of an orderbook, operations on
+// the orderbook are serialized by it so that reports are sent to the request
+// which causes them.
+type reportCollector struct {
+	sync.Mutex
+	reports []*order.Report
+}
+
+// report is the reporter of orderbook.
+func (c *reportCollector) report(report *order.Report) {
+	c.reports = append(c.reports, report)
+}
+
+// collect runs the operation on orderbook and returns its reports.
+func (c *reportCollector) collect(operation func()) []*order.Report {
+	c.Lock()
+	defer c.Unlock()
+
+	c.reports = []*order.Report{}
+	operation()
+
+	reports := c.reports
+	c.reports = nil
+
+	return reports
+}
+
+// reportFeed routes execution reports of an orderbook to the subscribers of
+// their order owners.
+type reportFeed struct {
+	sync.Mutex
+	owners map[uint64]*feed
+}
+
+func newReportFeed() *reportFeed {
+	return &reportFeed{
+		owners: map[uint64]*feed{},
+	}
+}
+
+// subscribe adds a subscriber of execution reports of the owner, the slow
+// subscriber is disconnected.
+func (f *reportFeed) subscribe(ownerID uint64, size int) *subscriber {
+	f.Lock()
+	defer f.Unlock()
+
+	ownerFeed, ok := f.owners[ownerID]
+	if !ok {
+		ownerFeed = newFeed()
+		f.owners[ownerID] = ownerFeed
+	}
+
+	return ownerFeed.subscribe(size, disconnectSlowSubscriber)
+}
+
+// unsubscribe removes the subscriber of the owner, and the feed of the owner
+// without subscribers.
+func (f *reportFeed) unsubscribe(ownerID uint64, sub *subscriber) {
+	f.Lock()
+	defer f.Unlock()
+
+	ownerFeed, ok := f.owners[ownerID]
+	if !ok {
+		return
+	}
+
+	ownerFeed.unsubscribe(sub)
+
+	ownerFeed.Lock()
+	defer ownerFeed.Unlock()
+
+	if len(ownerFeed.subscribers) == 0 {
+		delete(f.owners, ownerID)
+	}
+}
+
+// publish sends the report to the subscribers of its order owner, reports of
+// trades are sent to owners of both the taker and the maker.
+func (f *reportFeed) publish(report *order.Report) {
+	f.Lock()
+	defer f.Unlock()
+
+	if report.Order != nil {
+		f.publishTo(report.Order.OwnerID, report)
+		return
+	}
+
+	takerOwnerID, makerOwnerID := report.Trade.TakerOwnerID, report.Trade.MakerOwnerID
+	if takerOwnerID == makerOwnerID {
+		f.publishTo(takerOwnerID, report)
+		return
+	}
+
+	f.publishTo(takerOwnerID, tradeReport(report, true, false))
+	f.publishTo(makerOwnerID, tradeReport(report, false, true))
+}
+
+func (f *reportFeed) publishTo(ownerID uint64, report *order.Report) {
+	if ownerFeed, ok := f.owners[ownerID]; ok {
+		ownerFeed.publish(report)
+	}
+}
+
+// tradeReport returns the report of trade seen by its taker, maker or the
+// market, orders and owners of the other sides are hidden.
+func tradeReport(report *order.Report, taker, maker bool) *order.Report {
+	switch {
+	case taker && maker:
+		return report
+
+	case taker:
+		return &order.Report{Trade: report.Trade.TakerView(), Reason: report.Reason}
+
+	case maker:
+		return &order.Report{Trade: report.Trade.MakerView(), Reason: report.Reason}
+
+	default:
+		return &order.Report{Trade: report.Trade.PublicView(), Reason: report.Reason}
+	}
+}
+
+// ordersReports returns the reports of orders with specified ids, reports of
+// other orders are left out and their trades are hidden.
+func ordersReports(reports []*order.Report, ids ...uint64) []*order.Report {
+	contains := func(id uint64) bool {
+		for _, orderID := range ids {
+			if orderID == id {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	filtered := []*order.Report{}
+	for _, report := range reports {
+		if report.Order != nil {
+			if contains(report.Order.ID) {
+				filtered = append(filtered, report)
+			}
+
+			continue
+		}
+
+		taker, maker := contains(report.Trade.TakerID), contains(report.Trade.MakerID)
+		if taker || maker {
+			filtered = append(filtered, tradeReport(report, taker, maker))
+		}
+	}
+
+	return filtered
+}
+
+// tradesReports returns the reports of trades seen by the market.
+func tradesReports(reports []*order.Report) []*order.Report {
+	filtered := []*order.Report{}
+	for _, report := range reports {
+		if report.Trade != nil {
+			filtered = append(filtered, tradeReport(report, false, false))
+		}
+	}
+
+	return filtered
+}
+
+// tradeStream is the server stream of trades.
+type tradeStream interface {
+	Send(*oceanbookpb.Trade) error
+}
+
+// sendTrades sends trades in execution reports to the stream, state changes
+// of orders are sent to subscribers of execution reports only.
+func sendTrades(reports []*order.Report, stream tradeStream) {
+	for _, report := range reports {
+		if report.Trade != nil {
+			stream.Send(report.Trade.Serialize())
+		}
+	}
+}
+
+// serializeReport returns protobuf encoded execution report of the orderbook.
+func serializeReport(symbol string, report *order.Report) *oceanbookpb.ExecutionReport {
+	serialized := report.Serialize()
+	if serialized.Order != nil {
+		serialized.Order.Symbol = symbol
+	}
+
+	return serialized
+}
@@ -4,12 +4,17 @@ import (
 	"context"
 	"errors"
 	"sync"
+	"time"
 
 	"github.com/draveness/oceanbook/api/protobuf-spec/oceanbookpb"
 	"github.com/draveness/oceanbook/pkg/order"
 	"github.com/draveness/oceanbook/pkg/orderbook"
+	"github.com/draveness/oceanbook/pkg/trade"
+	"github.com/golang/protobuf/ptypes"
 	"github.com/shopspring/decimal"
 	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 var (
@@ -22,20 +27,107 @@ var (
 	// ErrInvalidOrderQuantity returns when order quantity is invalid.
 	ErrInvalidOrderQuantity = errors.New("invalid order quantity")
 
+	// ErrInvalidOrderQuoteQuantity returns when order quote quantity is invalid.
+	ErrInvalidOrderQuoteQuantity = errors.New("invalid order quote quantity")
+
+	// ErrInvalidOrderStopPrice returns when order stop price is invalid.
+	ErrInvalidOrderStopPrice = errors.New("invalid order stop price")
+
+	// ErrInvalidOrderTrigger returns when stop order trigger is invalid.
+	ErrInvalidOrderTrigger = errors.New("invalid order trigger")
+
+	// ErrInvalidOrderStopType returns when stop order type is invalid.
+	ErrInvalidOrderStopType = errors.New("invalid order stop type")
+
+	// ErrInvalidOrderTrailingOffset returns when trailing amount or percentage is invalid.
+	ErrInvalidOrderTrailingOffset = errors.New("invalid order trailing offset")
+
+	// ErrInvalidOrderDisplayQuantity returns when order display quantity is invalid.
+	ErrInvalidOrderDisplayQuantity = errors.New("invalid order display quantity")
+
 	// ErrInvalidOrderSide returns when order side is invalid.
 	ErrInvalidOrderSide = errors.New("invalid order side")
+
+	// ErrInvalidOrderExpiry returns when order expiry time is invalid.
+	ErrInvalidOrderExpiry = errors.New("invalid order expiry")
+
+	// ErrInvalidSelfTradePrevention returns when self trade prevention mode is invalid.
+	ErrInvalidSelfTradePrevention = errors.New("invalid self trade prevention")
+
+	// ErrInvalidMarketSpec returns when trading rules of orderbook are invalid.
+	ErrInvalidMarketSpec = errors.New("invalid market spec")
+
+	// ErrInvalidMatcher returns when matching algorithm of orderbook is invalid.
+	ErrInvalidMatcher = errors.New("invalid matcher")
+
+	// ErrInvalidMarketProtection returns when market protection of orderbook is invalid.
+	ErrInvalidMarketProtection = errors.New("invalid market protection")
+
+	// ErrInvalidCircuitBreaker returns when circuit breaker of orderbook is invalid.
+	ErrInvalidCircuitBreaker = errors.New("invalid circuit breaker")
+
+	// ErrInvalidDepthIncrement returns when price increment of depth is invalid.
+	ErrInvalidDepthIncrement = errors.New("invalid depth increment")
+
+	// ErrSlowSubscriber returns when the subscriber falls behind market data
+	// feed and is disconnected.
+	ErrSlowSubscriber = errors.New("slow subscriber")
+
+	// ErrInvalidSlowSubscriberPolicy returns when slow subscriber policy is invalid.
+	ErrInvalidSlowSubscriberPolicy = errors.New("invalid slow subscriber policy")
 )
 
+const (
+	// defaultPageSize is the number of orders in a page when it is not specified.
+	defaultPageSize = 100
+
+	// maxPageSize is the maximum number of orders in a page.
+	maxPageSize = 1000
+
+	// subscriberBufferSize is the number of events buffered for a subscriber
+	// of market data feed.
+	subscriberBufferSize = 1024
+)
+
+// slowSubscriberPolicies maps protobuf slow subscriber policies to feed
+// policies.
+var slowSubscriberPolicies = map[oceanbookpb.SubscribeTradesRequest_SlowSubscriberPolicy]slowSubscriberPolicy{
+	oceanbookpb.SubscribeTradesRequest_DISCONNECT: disconnectSlowSubscriber,
+	oceanbookpb.SubscribeTradesRequest_DROP:       dropSlowSubscriberEvents,
+}
+
+// tradingStates maps protobuf trading states to orderbook trading states.
+var tradingStates = map[oceanbookpb.TradingState]orderbook.TradingState{
+	oceanbookpb.TradingState_CONTINUOUS:  orderbook.TradingStateContinuous,
+	oceanbookpb.TradingState_PRE_OPEN:    orderbook.TradingStatePreOpen,
+	oceanbookpb.TradingState_POST_ONLY:   orderbook.TradingStatePostOnly,
+	oceanbookpb.TradingState_CANCEL_ONLY: orderbook.TradingStateCancelOnly,
+	oceanbookpb.TradingState_HALTED:      orderbook.TradingStateHalted,
+	oceanbookpb.TradingState_CLOSED:      orderbook.TradingStateClosed,
+}
+
 // Service represents oceanbook service.
 type Service struct {
 	sync.RWMutex
 	orderbooks map[string]*orderbook.OrderBook
+	collectors map[string]*reportCollector
+	depthFeeds map[string]*feed
+	tradeFeeds map[string]*feed
+	orderFeeds map[string]*feed
+
+	reportFeeds map[string]*reportFeed
 }
 
 // NewService returns an oceanbook service.
 func NewService() *Service {
 	return &Service{
 		orderbooks: map[string]*orderbook.OrderBook{},
+		collectors: map[string]*reportCollector{},
+		depthFeeds: map[string]*feed{},
+		tradeFeeds: map[string]*feed{},
+		orderFeeds: map[string]*feed{},
+
+		reportFeeds: map[string]*reportFeed{},
 	}
 }
 
@@ -48,6 +140,16 @@ func (s *Service) getOrderBook(symbol string) (*orderbook.OrderBook, bool) {
 	return orderbook, ok
 }
 
+// collectReports runs the operation on orderbook with the symbol and returns
+// execution reports caused by it.
+func (s *Service) collectReports(symbol string, operation func()) []*order.Report {
+	s.RLock()
+	collector := s.collectors[symbol]
+	s.RUnlock()
+
+	return collector.collect(operation)
+}
+
 // GetDepth .
 func (s *Service) GetDepth(ctx context.Context, request *oceanbookpb.GetDepthRequest) (*oceanbookpb.Depth, error) {
 	od, exists := s.getOrderBook(request.Symbol)
@@ -55,11 +157,196 @@ func (s *Service) GetDepth(ctx context.Context, request *oceanbookpb.GetDepthReq
 		return nil, ErrOrderBookNotFound
 	}
 
-	depth := od.GetDepth()
+	increment := decimal.Zero
+	if request.Increment != "" {
+		var err error
+		increment, err = decimal.NewFromString(request.Increment)
+		if err != nil || !increment.IsPositive() {
+			return nil, ErrInvalidDepthIncrement
+		}
+	}
+
+	depth := od.AggregateDepth(increment, int(request.Limit))
 
 	return depth.Serialize(), nil
 }
 
+// SubscribeDepth sends the depth snapshot of orderbook followed by its
+// updates, updates carry increasing sequence numbers and the stream ends with
+// ErrSlowSubscriber when the subscriber falls behind.
+func (s *Service) SubscribeDepth(request *oceanbookpb.SubscribeDepthRequest, stream oceanbookpb.Oceanbook_SubscribeDepthServer) error {
+	od, exists := s.getOrderBook(request.Symbol)
+	if !exists {
+		return ErrOrderBookNotFound
+	}
+
+	s.RLock()
+	depthFeed := s.depthFeeds[request.Symbol]
+	s.RUnlock()
+
+	// subscriber is added before taking the snapshot so that no update after
+	// the snapshot is missed, and updates included in it are skipped.
+	sub := depthFeed.subscribe(subscriberBufferSize, disconnectSlowSubscriber)
+	defer depthFeed.unsubscribe(sub)
+
+	snapshot := od.GetDepth()
+	err := stream.Send(&oceanbookpb.DepthEvent{
+		Event: &oceanbookpb.DepthEvent_Snapshot{Snapshot: snapshot.Serialize()},
+	})
+	if err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+
+		case event, ok := <-sub.events:
+			if !ok {
+				return ErrSlowSubscriber
+			}
+
+			update := event.(*orderbook.DepthUpdate)
+			if update.Sequence <= snapshot.Sequence {
+				continue
+			}
+
+			err := stream.Send(&oceanbookpb.DepthEvent{
+				Event: &oceanbookpb.DepthEvent_Update{Update: update.Serialize()},
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// SubscribeTrades sends trades of orderbook as they happen without their
+// orders and owners, the slow subscriber policy of request decides whether
+// trades are dropped or the stream ends with ErrSlowSubscriber when the
+// subscriber falls behind. Trades carry consecutive ids, and gaps in ids are
+// the dropped trades.
+func (s *Service) SubscribeTrades(request *oceanbookpb.SubscribeTradesRequest, stream oceanbookpb.Oceanbook_SubscribeTradesServer) error {
+	policy, ok := slowSubscriberPolicies[request.SlowSubscriberPolicy]
+	if !ok {
+		return ErrInvalidSlowSubscriberPolicy
+	}
+
+	s.RLock()
+	tradeFeed, exists := s.tradeFeeds[request.Symbol]
+	s.RUnlock()
+
+	if !exists {
+		return ErrOrderBookNotFound
+	}
+
+	sub := tradeFeed.subscribe(subscriberBufferSize, policy)
+	defer tradeFeed.unsubscribe(sub)
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+
+		case event, ok := <-sub.events:
+			if !ok {
+				return ErrSlowSubscriber
+			}
+
+			serialized := event.(*trade.Trade).PublicView().Serialize()
+			serialized.Symbol = request.Symbol
+
+			if err := stream.Send(serialized); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// SubscribeOrderBook sends the anonymised resting orders of orderbook followed
+// by order events, events carry increasing sequence numbers and the stream
+// ends with ErrSlowSubscriber when the subscriber falls behind.
+func (s *Service) SubscribeOrderBook(request *oceanbookpb.SubscribeOrderBookRequest, stream oceanbookpb.Oceanbook_SubscribeOrderBookServer) error {
+	od, exists := s.getOrderBook(request.Symbol)
+	if !exists {
+		return ErrOrderBookNotFound
+	}
+
+	s.RLock()
+	orderFeed := s.orderFeeds[request.Symbol]
+	s.RUnlock()
+
+	// subscriber is added before taking the snapshot so that no event after
+	// the snapshot is missed, and events included in it are skipped.
+	sub := orderFeed.subscribe(subscriberBufferSize, disconnectSlowSubscriber)
+	defer orderFeed.unsubscribe(sub)
+
+	snapshot := od.GetSnapshot()
+	err := stream.Send(&oceanbookpb.OrderBookEvent{
+		Event: &oceanbookpb.OrderBookEvent_Snapshot{Snapshot: snapshot.Serialize()},
+	})
+	if err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+
+		case event, ok := <-sub.events:
+			if !ok {
+				return ErrSlowSubscriber
+			}
+
+			orderEvent := event.(*orderbook.OrderEvent)
+			if orderEvent.Sequence <= snapshot.Sequence {
+				continue
+			}
+
+			err := stream.Send(&oceanbookpb.OrderBookEvent{
+				Event: &oceanbookpb.OrderBookEvent_Update{Update: orderEvent.Serialize()},
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// SubscribeExecutionReports sends execution reports of orders of the owner as
+// they happen, including fills as maker, expiries and cancellations, and the
+// stream ends with ErrSlowSubscriber when the subscriber falls behind.
+func (s *Service) SubscribeExecutionReports(request *oceanbookpb.SubscribeExecutionReportsRequest, stream oceanbookpb.Oceanbook_SubscribeExecutionReportsServer) error {
+	s.RLock()
+	reportFeed, exists := s.reportFeeds[request.Symbol]
+	s.RUnlock()
+
+	if !exists {
+		return ErrOrderBookNotFound
+	}
+
+	sub := reportFeed.subscribe(request.OwnerId, subscriberBufferSize)
+	defer reportFeed.unsubscribe(request.OwnerId, sub)
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+
+		case event, ok := <-sub.events:
+			if !ok {
+				return ErrSlowSubscriber
+			}
+
+			if err := stream.Send(serializeReport(request.Symbol, event.(*order.Report))); err != nil {
+				return err
+			}
+		}
+	}
+}
+
 // NewOrderBook .
 func (s *Service) NewOrderBook(ctx context.Context, request *oceanbookpb.NewOrderBookRequest) (*oceanbookpb.NewOrderBookResponse, error) {
 	_, exists := s.getOrderBook(request.Symbol)
@@ -67,31 +354,337 @@ func (s *Service) NewOrderBook(ctx context.Context, request *oceanbookpb.NewOrde
 		return &oceanbookpb.NewOrderBookResponse{}, nil
 	}
 
+	options := []orderbook.Option{}
+	if request.MarketSpec != nil {
+		spec, err := parseMarketSpec(request.MarketSpec)
+		if err != nil {
+			return nil, err
+		}
+
+		options = append(options, orderbook.WithMarketSpec(spec))
+	}
+
+	if request.MarketProtection != nil {
+		protection, err := parseMarketProtection(request.MarketProtection)
+		if err != nil {
+			return nil, err
+		}
+
+		options = append(options, orderbook.WithMarketProtection(protection))
+	}
+
+	if request.Matcher != nil {
+		matcher, err := parseMatcher(request.Matcher)
+		if err != nil {
+			return nil, err
+		}
+
+		options = append(options, orderbook.WithMatcher(matcher))
+	}
+
+	if request.CircuitBreaker != nil {
+		circuitBreaker, err := parseCircuitBreaker(request.CircuitBreaker)
+		if err != nil {
+			return nil, err
+		}
+
+		options = append(options, orderbook.WithCircuitBreaker(circuitBreaker))
+	}
+
+	state, ok := tradingStates[request.TradingState]
+	if !ok {
+		return nil, orderbook.ErrInvalidTradingState
+	}
+	options = append(options, orderbook.WithTradingState(state))
+
+	collector := &reportCollector{}
+	reportFeed := newReportFeed()
+	tradeFeed := newFeed()
+	options = append(options, orderbook.WithReporter(func(report *order.Report) {
+		collector.report(report)
+		reportFeed.publish(report)
+
+		if report.Trade != nil {
+			tradeFeed.publish(report.Trade)
+		}
+	}))
+
+	depthFeed := newFeed()
+	options = append(options, orderbook.WithDepthListener(func(update *orderbook.DepthUpdate) {
+		depthFeed.publish(update)
+	}))
+
+	orderFeed := newFeed()
+	options = append(options, orderbook.WithOrderListener(func(event *orderbook.OrderEvent) {
+		orderFeed.publish(event)
+	}))
+
 	s.Lock()
 	defer s.Unlock()
 
-	s.orderbooks[request.Symbol] = orderbook.NewOrderBook(request.Symbol)
+	s.orderbooks[request.Symbol] = orderbook.NewOrderBook(request.Symbol, options...)
+	s.collectors[request.Symbol] = collector
+	s.depthFeeds[request.Symbol] = depthFeed
+	s.tradeFeeds[request.Symbol] = tradeFeed
+	s.orderFeeds[request.Symbol] = orderFeed
+	s.reportFeeds[request.Symbol] = reportFeed
 
 	log.Infof("[oceanbook.liquidity] new order book with symbol %s", request.Symbol)
 
 	return &oceanbookpb.NewOrderBookResponse{}, nil
 }
 
-// InsertOrder .
+// parseMarketSpec builds trading rules of orderbook from request, empty
+// fields leave the rules unchanged.
+func parseMarketSpec(request *oceanbookpb.MarketSpec) (orderbook.MarketSpec, error) {
+	var err error
+	parse := func(value string) decimal.Decimal {
+		if value == "" || err != nil {
+			return decimal.Zero
+		}
+
+		d, parseErr := decimal.NewFromString(value)
+		if parseErr != nil || d.IsNegative() {
+			err = ErrInvalidMarketSpec
+		}
+
+		return d
+	}
+
+	spec := orderbook.MarketSpec{
+		TickSize:          parse(request.TickSize),
+		LotSize:           parse(request.LotSize),
+		PricePrecision:    int32(request.PricePrecision),
+		QuantityPrecision: int32(request.QuantityPrecision),
+		MinQuantity:       parse(request.MinQuantity),
+		MaxQuantity:       parse(request.MaxQuantity),
+		MinNotional:       parse(request.MinNotional),
+	}
+	if err != nil {
+		return orderbook.MarketSpec{}, err
+	}
+
+	if spec.MaxQuantity.IsPositive() && spec.MinQuantity.GreaterThan(spec.MaxQuantity) {
+		return orderbook.MarketSpec{}, ErrInvalidMarketSpec
+	}
+
+	return spec, nil
+}
+
+// parseMatcher builds matching algorithm of orderbook from request.
+func parseMatcher(request *oceanbookpb.Matcher) (orderbook.Matcher, error) {
+	switch request.Algorithm {
+	case oceanbookpb.Matcher_FIFO:
+		return orderbook.FIFOMatcher{}, nil
+
+	case oceanbookpb.Matcher_PRO_RATA:
+		return orderbook.ProRataMatcher{}, nil
+
+	case oceanbookpb.Matcher_PRO_RATA_TOP_ORDER:
+		topOrderCap := decimal.Zero
+		if request.TopOrderCap != "" {
+			var err error
+			topOrderCap, err = decimal.NewFromString(request.TopOrderCap)
+			if err != nil || topOrderCap.IsNegative() {
+				return nil, ErrInvalidMatcher
+			}
+		}
+
+		return orderbook.ProRataTopOrderMatcher{TopOrderCap: topOrderCap}, nil
+
+	default:
+		return nil, ErrInvalidMatcher
+	}
+}
+
+// parseMarketProtection builds market protection of orderbook from request.
+func parseMarketProtection(request *oceanbookpb.MarketProtection) (orderbook.MarketProtection, error) {
+	deviation, err := decimal.NewFromString(request.Deviation)
+	if err != nil || !deviation.IsPositive() {
+		return orderbook.MarketProtection{}, ErrInvalidMarketProtection
+	}
+
+	var reference orderbook.ProtectionReference
+	switch request.Reference {
+	case oceanbookpb.MarketProtection_LAST_PRICE:
+		reference = orderbook.ProtectionReferenceLastPrice
+
+	case oceanbookpb.MarketProtection_BEST_PRICE:
+		reference = orderbook.ProtectionReferenceBestPrice
+
+	default:
+		return orderbook.MarketProtection{}, ErrInvalidMarketProtection
+	}
+
+	return orderbook.MarketProtection{
+		Deviation:     deviation,
+		Reference:     reference,
+		RestRemainder: request.RestRemainder,
+	}, nil
+}
+
+// parseCircuitBreaker builds circuit breaker of orderbook from request.
+func parseCircuitBreaker(request *oceanbookpb.CircuitBreaker) (orderbook.CircuitBreaker, error) {
+	band, err := decimal.NewFromString(request.Band)
+	if err != nil || !band.IsPositive() || request.WindowSeconds < 0 {
+		return orderbook.CircuitBreaker{}, ErrInvalidCircuitBreaker
+	}
+
+	return orderbook.CircuitBreaker{
+		Band:            band,
+		Window:          time.Duration(request.WindowSeconds) * time.Second,
+		ReopenByAuction: request.ReopenByAuction,
+	}, nil
+}
+
+// InsertOrder inserts the order and streams its trades, execution reports of
+// the order and makers and stop orders affected by it are sent to subscribers
+// of their owners.
 func (s *Service) InsertOrder(request *oceanbookpb.InsertOrderRequest, stream oceanbookpb.Oceanbook_InsertOrderServer) error {
 	od, exists := s.getOrderBook(request.Symbol)
 	if !exists {
 		return ErrOrderBookNotFound
 	}
 
-	price, err := decimal.NewFromString(request.Price)
+	newOrder, err := parseOrder(request)
+	if err != nil {
+		return err
+	}
+
+	reports := s.collectReports(request.Symbol, func() {
+		_, err = od.InsertOrder(newOrder)
+	})
+
+	// trades are sent before the error, market order may trade before its
+	// remainder is cancelled by market protection.
+	sendTrades(ordersReports(reports, newOrder.ID), stream)
+
+	return tradingStateError(od, err)
+}
+
+// InsertOCOOrder inserts two linked orders, a fill or trigger of one order
+// cancels the other one.
+func (s *Service) InsertOCOOrder(request *oceanbookpb.InsertOCOOrderRequest, stream oceanbookpb.Oceanbook_InsertOCOOrderServer) error {
+	od, exists := s.getOrderBook(request.Symbol)
+	if !exists {
+		return ErrOrderBookNotFound
+	}
+
+	if request.First == nil || request.Second == nil {
+		return orderbook.ErrInvalidOCOOrder
+	}
+
+	first, err := parseOrder(request.First)
 	if err != nil {
-		return ErrInvalidOrderPrice
+		return err
 	}
+	first.GroupID = request.GroupId
+
+	second, err := parseOrder(request.Second)
+	if err != nil {
+		return err
+	}
+	second.GroupID = request.GroupId
+
+	reports := s.collectReports(request.Symbol, func() {
+		_, err = od.InsertOCOOrder(first, second)
+	})
+
+	sendTrades(ordersReports(reports, first.ID, second.ID), stream)
 
-	quantity, err := decimal.NewFromString(request.Quantity)
+	return tradingStateError(od, err)
+}
+
+// parseOrder builds an order from the insert order request.
+func parseOrder(request *oceanbookpb.InsertOrderRequest) (*order.Order, error) {
+	price, err := decimal.NewFromString(request.Price)
 	if err != nil {
-		return ErrInvalidOrderQuantity
+		return nil, ErrInvalidOrderPrice
+	}
+
+	// quantity is omitted by market orders sized by quote amount.
+	quantity := decimal.Zero
+	if request.Quantity != "" || request.QuoteQuantity == "" {
+		quantity, err = decimal.NewFromString(request.Quantity)
+		if err != nil {
+			return nil, ErrInvalidOrderQuantity
+		}
+	}
+
+	quoteQuantity := decimal.Zero
+	if request.QuoteQuantity != "" {
+		quoteQuantity, err = decimal.NewFromString(request.QuoteQuantity)
+		if err != nil || !quoteQuantity.IsPositive() {
+			return nil, ErrInvalidOrderQuoteQuantity
+		}
+	}
+
+	stopPrice := decimal.Zero
+	if request.StopPrice != "" {
+		stopPrice, err = decimal.NewFromString(request.StopPrice)
+		if err != nil || stopPrice.IsNegative() {
+			return nil, ErrInvalidOrderStopPrice
+		}
+	}
+
+	var trigger order.Trigger
+	switch request.Trigger {
+	case oceanbookpb.Order_DEFAULT_TRIGGER:
+
+	case oceanbookpb.Order_RISES_TO:
+		trigger = order.TriggerRisesTo
+
+	case oceanbookpb.Order_FALLS_TO:
+		trigger = order.TriggerFallsTo
+
+	default:
+		return nil, ErrInvalidOrderTrigger
+	}
+
+	var stopType order.StopType
+	switch request.StopType {
+	case oceanbookpb.Order_DEFAULT_STOP_TYPE:
+
+	case oceanbookpb.Order_STOP_LIMIT:
+		stopType = order.StopTypeLimit
+
+	case oceanbookpb.Order_STOP_MARKET:
+		stopType = order.StopTypeMarket
+
+	default:
+		return nil, ErrInvalidOrderStopType
+	}
+
+	trailingAmount := decimal.Zero
+	if request.TrailingAmount != "" {
+		trailingAmount, err = decimal.NewFromString(request.TrailingAmount)
+		if err != nil || trailingAmount.IsNegative() {
+			return nil, ErrInvalidOrderTrailingOffset
+		}
+	}
+
+	trailingPercent := decimal.Zero
+	if request.TrailingPercent != "" {
+		trailingPercent, err = decimal.NewFromString(request.TrailingPercent)
+		if err != nil || trailingPercent.IsNegative() {
+			return nil, ErrInvalidOrderTrailingOffset
+		}
+	}
+
+	displayQuantity := decimal.Zero
+	if request.DisplayQuantity != "" {
+		displayQuantity, err = decimal.NewFromString(request.DisplayQuantity)
+		if err != nil || displayQuantity.IsNegative() {
+			return nil, ErrInvalidOrderDisplayQuantity
+		}
+	}
+
+	var expiresAt time.Time
+	if request.ExpiresAt != nil {
+		expiresAt, err = ptypes.Timestamp(request.ExpiresAt)
+		if err != nil {
+			return nil, ErrInvalidOrderExpiry
+		}
 	}
 
 	var side order.Side
@@ -103,33 +696,374 @@ func (s *Service) InsertOrder(request *oceanbookpb.InsertOrderRequest, stream oc
 		side = order.SideBid
 
 	default:
-		return ErrInvalidOrderSide
+		return nil, ErrInvalidOrderSide
+	}
+
+	var selfTradePrevention order.SelfTradePrevention
+	switch request.SelfTradePrevention {
+	case oceanbookpb.Order_CANCEL_NEWEST:
+		selfTradePrevention = order.SelfTradePreventionCancelNewest
+
+	case oceanbookpb.Order_CANCEL_OLDEST:
+		selfTradePrevention = order.SelfTradePreventionCancelOldest
+
+	case oceanbookpb.Order_CANCEL_BOTH:
+		selfTradePrevention = order.SelfTradePreventionCancelBoth
+
+	case oceanbookpb.Order_DECREMENT_AND_CANCEL:
+		selfTradePrevention = order.SelfTradePreventionDecrementAndCancel
+
+	default:
+		return nil, ErrInvalidSelfTradePrevention
+	}
+
+	return &order.Order{
+		ID:                request.Id,
+		Side:              side,
+		Price:             price,
+		StopPrice:         stopPrice,
+		Trigger:           trigger,
+		StopType:          stopType,
+		TrailingAmount:    trailingAmount,
+		TrailingPercent:   trailingPercent,
+		Quantity:          quantity,
+		QuoteQuantity:     quoteQuantity,
+		ExpiresAt:         expiresAt,
+		ImmediateOrCancel: request.ImmediateOrCancel,
+		FillOrKill:        request.FillOrKill,
+		PostOnly:          request.PostOnly,
+		PostOnlySlide:     request.PostOnlySlide,
+		DisplayQuantity:   displayQuantity,
+
+		OwnerID:             request.OwnerId,
+		SelfTradePrevention: selfTradePrevention,
+	}, nil
+}
+
+// AmendOrder .
+func (s *Service) AmendOrder(request *oceanbookpb.AmendOrderRequest, stream oceanbookpb.Oceanbook_AmendOrderServer) error {
+	od, exists := s.getOrderBook(request.Symbol)
+	if !exists {
+		return ErrOrderBookNotFound
+	}
+
+	price := decimal.Zero
+	if request.Price != "" {
+		var err error
+		price, err = decimal.NewFromString(request.Price)
+		if err != nil || price.IsNegative() {
+			return ErrInvalidOrderPrice
+		}
 	}
 
-	trades := od.InsertOrder(&order.Order{
-		ID:       request.Id,
-		Side:     side,
-		Price:    price,
-		Quantity: quantity,
+	quantity := decimal.Zero
+	if request.Quantity != "" {
+		var err error
+		quantity, err = decimal.NewFromString(request.Quantity)
+		if err != nil || quantity.IsNegative() {
+			return ErrInvalidOrderQuantity
+		}
+	}
+
+	var err error
+	reports := s.collectReports(request.Symbol, func() {
+		_, err = od.AmendOrder(&order.Order{
+			ID:       request.OrderId,
+			Price:    price,
+			Quantity: quantity,
+		})
 	})
 
-	for _, trade := range trades {
-		stream.Send(trade.Serialize())
+	sendTrades(ordersReports(reports, request.OrderId), stream)
+
+	return tradingStateError(od, err)
+}
+
+// CancelOrder cancels the resting or stop order and returns the cancelled order.
+func (s *Service) CancelOrder(ctx context.Context, request *oceanbookpb.CancelOrderRequest) (*oceanbookpb.CancelOrderResponse, error) {
+	od, exists := s.getOrderBook(request.Symbol)
+	if !exists {
+		return nil, ErrOrderBookNotFound
+	}
+
+	var cancelledOrder *order.Order
+	var err error
+	s.collectReports(request.Symbol, func() {
+		cancelledOrder, err = od.CancelOrder(&order.Order{
+			ID: request.OrderId,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	serialized := cancelledOrder.Serialize()
+	serialized.Symbol = od.Symbol
+
+	return &oceanbookpb.CancelOrderResponse{
+		Order: serialized,
+	}, nil
+}
+
+// GetOrder returns the resting or stop order with specified id.
+func (s *Service) GetOrder(ctx context.Context, request *oceanbookpb.GetOrderRequest) (*oceanbookpb.Order, error) {
+	od, exists := s.getOrderBook(request.Symbol)
+	if !exists {
+		return nil, ErrOrderBookNotFound
+	}
+
+	o, err := od.GetOrder(request.OrderId)
+	if err != nil {
+		return nil, err
+	}
+
+	serialized := o.Serialize()
+	serialized.Symbol = od.Symbol
+
+	return serialized, nil
+}
+
+// ListOpenOrders returns a page of resting and stop orders sorted by id, the
+// page token is the last order id of the previous page.
+func (s *Service) ListOpenOrders(ctx context.Context, request *oceanbookpb.ListOpenOrdersRequest) (*oceanbookpb.ListOpenOrdersResponse, error) {
+	od, exists := s.getOrderBook(request.Symbol)
+	if !exists {
+		return nil, ErrOrderBookNotFound
 	}
 
+	filter := orderbook.OrderFilter{
+		OwnerID: request.OwnerId,
+		Limit:   defaultPageSize,
+	}
+
+	// page token is the id after the last order of previous page, and zero
+	// token starts from the first page.
+	if request.PageToken > 0 {
+		afterID := request.PageToken - 1
+		filter.AfterID = &afterID
+	}
+
+	if request.PageSize > 0 {
+		filter.Limit = int(request.PageSize)
+	}
+
+	if filter.Limit > maxPageSize {
+		filter.Limit = maxPageSize
+	}
+
+	sides, err := parseSides(request.Sides)
+	if err != nil {
+		return nil, err
+	}
+	filter.Sides = sides
+
+	orders := od.ListOpenOrders(filter)
+
+	response := &oceanbookpb.ListOpenOrdersResponse{
+		Orders: make([]*oceanbookpb.Order, 0, len(orders)),
+	}
+	for _, o := range orders {
+		serialized := o.Serialize()
+		serialized.Symbol = od.Symbol
+		response.Orders = append(response.Orders, serialized)
+	}
+
+	// a full page may be followed by more orders.
+	if len(orders) == filter.Limit {
+		response.NextPageToken = orders[len(orders)-1].ID + 1
+	}
+
+	return response, nil
+}
+
+// MassCancel cancels all resting and stop orders of orderbook selected by
+// sides, owner and price range, and returns ids of cancelled orders.
+func (s *Service) MassCancel(ctx context.Context, request *oceanbookpb.MassCancelRequest) (*oceanbookpb.MassCancelResponse, error) {
+	od, exists := s.getOrderBook(request.Symbol)
+	if !exists {
+		return nil, ErrOrderBookNotFound
+	}
+
+	sides, err := parseSides(request.Sides)
+	if err != nil {
+		return nil, err
+	}
+
+	minPrice, err := parsePriceBound(request.MinPrice)
+	if err != nil {
+		return nil, err
+	}
+
+	maxPrice, err := parsePriceBound(request.MaxPrice)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := orderbook.OrderFilter{
+		Sides:    sides,
+		OwnerID:  request.OwnerId,
+		MinPrice: minPrice,
+		MaxPrice: maxPrice,
+	}
+
+	var cancelledIDs []uint64
+	s.collectReports(request.Symbol, func() {
+		cancelledIDs = od.MassCancel(filter)
+	})
+
+	return &oceanbookpb.MassCancelResponse{
+		OrderIds: cancelledIDs,
+	}, nil
+}
+
+// parsePriceBound parses the bound of price range, empty bound means the range
+// is unbounded.
+func parsePriceBound(value string) (decimal.Decimal, error) {
+	if value == "" {
+		return decimal.Zero, nil
+	}
+
+	price, err := decimal.NewFromString(value)
+	if err != nil || price.IsNegative() {
+		return decimal.Zero, ErrInvalidOrderPrice
+	}
+
+	return price, nil
+}
+
+// parseSides converts protobuf order sides into order sides.
+func parseSides(sides []oceanbookpb.Order_Side) ([]order.Side, error) {
+	parsedSides := []order.Side{}
+	for _, side := range sides {
+		switch side {
+		case oceanbookpb.Order_ASK:
+			parsedSides = append(parsedSides, order.SideAsk)
+
+		case oceanbookpb.Order_BID:
+			parsedSides = append(parsedSides, order.SideBid)
+
+		default:
+			return nil, ErrInvalidOrderSide
+		}
+	}
+
+	return parsedSides, nil
+}
+
+// StartAuction starts the call auction of orderbook.
+func (s *Service) StartAuction(ctx context.Context, request *oceanbookpb.StartAuctionRequest) (*oceanbookpb.StartAuctionResponse, error) {
+	od, exists := s.getOrderBook(request.Symbol)
+	if !exists {
+		return nil, ErrOrderBookNotFound
+	}
+
+	od.StartAuction()
+
+	return &oceanbookpb.StartAuctionResponse{}, nil
+}
+
+// Uncross ends the call auction of orderbook and streams the trades executed at
+// the equilibrium price without their orders and owners, execution reports of
+// the orders are sent to subscribers of their owners.
+func (s *Service) Uncross(request *oceanbookpb.UncrossRequest, stream oceanbookpb.Oceanbook_UncrossServer) error {
+	od, exists := s.getOrderBook(request.Symbol)
+	if !exists {
+		return ErrOrderBookNotFound
+	}
+
+	var err error
+	reports := s.collectReports(request.Symbol, func() {
+		_, err = od.Uncross()
+	})
+	if err != nil {
+		return err
+	}
+
+	sendTrades(tradesReports(reports), stream)
+
 	return nil
 }
 
-// CancelOrder .
-func (s *Service) CancelOrder(ctx context.Context, request *oceanbookpb.CancelOrderRequest) (*oceanbookpb.CancelOrderResponse, error) {
+// SetTradingState transits the trading state of orderbook.
+func (s *Service) SetTradingState(ctx context.Context, request *oceanbookpb.SetTradingStateRequest) (*oceanbookpb.SetTradingStateResponse, error) {
 	od, exists := s.getOrderBook(request.Symbol)
 	if !exists {
 		return nil, ErrOrderBookNotFound
 	}
 
-	od.CancelOrder(&order.Order{
-		ID: request.OrderId,
+	state, ok := tradingStates[request.State]
+	if !ok {
+		return nil, orderbook.ErrInvalidTradingState
+	}
+
+	// stop orders triggered while trading was suspended are inserted when
+	// continuous trading resumes.
+	var err error
+	s.collectReports(request.Symbol, func() {
+		err = od.SetTradingState(state)
 	})
+	if err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "orderbook %s: %s", od.Symbol, err)
+	}
+
+	return &oceanbookpb.SetTradingStateResponse{}, nil
+}
 
-	return &oceanbookpb.CancelOrderResponse{}, nil
+// GetTradingState returns the trading state of orderbook.
+func (s *Service) GetTradingState(ctx context.Context, request *oceanbookpb.GetTradingStateRequest) (*oceanbookpb.GetTradingStateResponse, error) {
+	od, exists := s.getOrderBook(request.Symbol)
+	if !exists {
+		return nil, ErrOrderBookNotFound
+	}
+
+	state := od.TradingState()
+	for pbState, tradingState := range tradingStates {
+		if tradingState == state {
+			return &oceanbookpb.GetTradingStateResponse{State: pbState}, nil
+		}
+	}
+
+	return nil, orderbook.ErrInvalidTradingState
+}
+
+// tradingStateError converts errors of operations forbidden by the trading
+// state of orderbook into failed precondition status.
+func tradingStateError(od *orderbook.OrderBook, err error) error {
+	switch err {
+	case orderbook.ErrInsertNotAllowed, orderbook.ErrAmendNotAllowed, orderbook.ErrInvalidAuctionOrder, orderbook.ErrCircuitBreakerTripped:
+		return status.Errorf(codes.FailedPrecondition, "orderbook %s is %s: %s", od.Symbol, od.TradingState(), err)
+
+	default:
+		return err
+	}
+}
+
+// ExpireOrders removes expired orders from all orderbooks every interval until
+// the context is done.
+func (s *Service) ExpireOrders(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case <-ticker.C:
+			s.RLock()
+			orderbooks := make([]*orderbook.OrderBook, 0, len(s.orderbooks))
+			for _, od := range s.orderbooks {
+				orderbooks = append(orderbooks, od)
+			}
+			s.RUnlock()
+
+			// reports of expired orders are sent to subscribers of their
+			// owners.
+			for _, od := range orderbooks {
+				s.collectReports(od.Symbol, func() {
+					od.ExpireOrders()
+				})
+			}
+		}
+	}
 }
@@ -2,11 +2,17 @@ package oceanbook
 
 import (
 	"context"
+	"runtime"
 	"testing"
+	"time"
 
 	"github.com/draveness/oceanbook/api/protobuf-spec/oceanbookpb"
+	"github.com/draveness/oceanbook/pkg/orderbook"
+	"github.com/golang/protobuf/ptypes"
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func TestNewOrderBook(t *testing.T) {
@@ -29,35 +35,1059 @@ func TestNewOrderBook(t *testing.T) {
 	assert.Equal(t, &oceanbookpb.NewOrderBookResponse{}, response)
 }
 
+func TestNewOrderBookWithMatcher(t *testing.T) {
+	svc := NewService()
+
+	_, err := svc.NewOrderBook(context.Background(), &oceanbookpb.NewOrderBookRequest{
+		Symbol: "BTC/CNY",
+		Matcher: &oceanbookpb.Matcher{
+			Algorithm:   oceanbookpb.Matcher_PRO_RATA_TOP_ORDER,
+			TopOrderCap: "invalid",
+		},
+	})
+	assert.Equal(t, ErrInvalidMatcher, err)
+
+	_, err = svc.NewOrderBook(context.Background(), &oceanbookpb.NewOrderBookRequest{
+		Symbol: "BTC/CNY",
+		Matcher: &oceanbookpb.Matcher{
+			Algorithm: oceanbookpb.Matcher_PRO_RATA,
+		},
+	})
+	assert.Nil(t, err)
+}
+
 type InsertOrderServer struct {
 	grpc.ServerStream
-	trades []*oceanbookpb.Trade
+	trades []*oceanbookpb.Trade
+}
+
+func NewTestInsertOrderServer() *InsertOrderServer {
+	return &InsertOrderServer{
+		trades: []*oceanbookpb.Trade{},
+	}
+}
+
+func (x *InsertOrderServer) Send(t *oceanbookpb.Trade) error {
+	x.trades = append(x.trades, t)
+
+	return nil
+}
+
+func TestInsertOrder(t *testing.T) {
+	svc := NewService()
+
+	request := &oceanbookpb.NewOrderBookRequest{
+		Symbol: "BTC/CNY",
+	}
+
+	newOrderBookResponse, err := svc.NewOrderBook(context.Background(), request)
+	assert.Nil(t, err)
+	assert.Equal(t, &oceanbookpb.NewOrderBookResponse{}, newOrderBookResponse)
+
+	stream := NewTestInsertOrderServer()
+	err = svc.InsertOrder(&oceanbookpb.InsertOrderRequest{
+		Id:       1,
+		Price:    "1.0",
+		Quantity: "2.0",
+		Symbol:   "BTC/CNY",
+		Side:     oceanbookpb.Order_ASK,
+	}, stream)
+	assert.Nil(t, err)
+	assert.Equal(t, []*oceanbookpb.Trade{}, stream.trades)
+
+	err = svc.InsertOrder(&oceanbookpb.InsertOrderRequest{
+		Id:       2,
+		Price:    "2.0",
+		Quantity: "1.0",
+		Symbol:   "BTC/CNY",
+		Side:     oceanbookpb.Order_BID,
+	}, stream)
+	assert.Nil(t, err)
+	assert.Len(t, stream.trades, 1)
+	assert.NotNil(t, stream.trades[0].CreatedAt)
+	stream.trades[0].CreatedAt = nil
+	assert.Equal(t, []*oceanbookpb.Trade{
+		{
+			Id:       1,
+			Price:    "1",
+			Quantity: "1",
+			TakerId:  2,
+		},
+	}, stream.trades)
+}
+
+func TestInsertStopOrder(t *testing.T) {
+	svc := NewService()
+
+	_, err := svc.NewOrderBook(context.Background(), &oceanbookpb.NewOrderBookRequest{
+		Symbol: "BTC/CNY",
+	})
+	assert.Nil(t, err)
+
+	stream := NewTestInsertOrderServer()
+	err = svc.InsertOrder(&oceanbookpb.InsertOrderRequest{
+		Id:        1,
+		Price:     "1.0",
+		Quantity:  "2.0",
+		StopPrice: "invalid",
+		Symbol:    "BTC/CNY",
+		Side:      oceanbookpb.Order_BID,
+	}, stream)
+	assert.Equal(t, ErrInvalidOrderStopPrice, err)
+
+	err = svc.InsertOrder(&oceanbookpb.InsertOrderRequest{
+		Id:        2,
+		Price:     "1.0",
+		Quantity:  "2.0",
+		StopPrice: "1.5",
+		Symbol:    "BTC/CNY",
+		Side:      oceanbookpb.Order_BID,
+		Trigger:   oceanbookpb.Order_RISES_TO,
+		StopType:  oceanbookpb.Order_STOP_MARKET,
+	}, stream)
+	assert.Equal(t, orderbook.ErrInvalidStopOrder, err)
+
+	err = svc.InsertOrder(&oceanbookpb.InsertOrderRequest{
+		Id:        3,
+		Price:     "1.0",
+		Quantity:  "2.0",
+		StopPrice: "1.5",
+		Symbol:    "BTC/CNY",
+		Side:      oceanbookpb.Order_BID,
+		Trigger:   oceanbookpb.Order_RISES_TO,
+		StopType:  oceanbookpb.Order_STOP_LIMIT,
+	}, stream)
+	assert.Nil(t, err)
+	assert.Equal(t, []*oceanbookpb.Trade{}, stream.trades)
+
+	od, _ := svc.getOrderBook("BTC/CNY")
+	assert.Equal(t, 1, od.StopBids.Size())
+}
+
+func TestInsertOCOOrder(t *testing.T) {
+	svc := NewService()
+
+	_, err := svc.NewOrderBook(context.Background(), &oceanbookpb.NewOrderBookRequest{
+		Symbol: "BTC/CNY",
+	})
+	assert.Nil(t, err)
+
+	stream := NewTestInsertOrderServer()
+	err = svc.InsertOCOOrder(&oceanbookpb.InsertOCOOrderRequest{
+		Symbol: "BTC/CNY",
+		First: &oceanbookpb.InsertOrderRequest{
+			Id:       1,
+			Price:    "2.0",
+			Quantity: "1.0",
+			Side:     oceanbookpb.Order_ASK,
+		},
+	}, stream)
+	assert.Equal(t, orderbook.ErrInvalidOCOOrder, err)
+
+	err = svc.InsertOCOOrder(&oceanbookpb.InsertOCOOrderRequest{
+		Symbol:  "BTC/CNY",
+		GroupId: 100,
+		First: &oceanbookpb.InsertOrderRequest{
+			Id:       1,
+			Price:    "2.0",
+			Quantity: "1.0",
+			Side:     oceanbookpb.Order_ASK,
+		},
+		Second: &oceanbookpb.InsertOrderRequest{
+			Id:        2,
+			Price:     "0.0",
+			Quantity:  "1.0",
+			StopPrice: "0.5",
+			Side:      oceanbookpb.Order_ASK,
+			Trigger:   oceanbookpb.Order_FALLS_TO,
+		},
+	}, stream)
+	assert.Nil(t, err)
+	assert.Equal(t, []*oceanbookpb.Trade{}, stream.trades)
+
+	err = svc.InsertOrder(&oceanbookpb.InsertOrderRequest{
+		Id:       3,
+		Price:    "2.0",
+		Quantity: "1.0",
+		Symbol:   "BTC/CNY",
+		Side:     oceanbookpb.Order_BID,
+	}, stream)
+	assert.Nil(t, err)
+	assert.Len(t, stream.trades, 1)
+
+	od, _ := svc.getOrderBook("BTC/CNY")
+	assert.Equal(t, 0, od.Asks.Size())
+	assert.Equal(t, 0, od.StopAsks.Size())
+}
+
+func TestInsertQuoteQuantityOrder(t *testing.T) {
+	svc := NewService()
+
+	_, err := svc.NewOrderBook(context.Background(), &oceanbookpb.NewOrderBookRequest{
+		Symbol: "BTC/CNY",
+	})
+	assert.Nil(t, err)
+
+	stream := NewTestInsertOrderServer()
+	err = svc.InsertOrder(&oceanbookpb.InsertOrderRequest{
+		Id:       1,
+		Price:    "2.0",
+		Quantity: "2.0",
+		Symbol:   "BTC/CNY",
+		Side:     oceanbookpb.Order_ASK,
+	}, stream)
+	assert.Nil(t, err)
+
+	err = svc.InsertOrder(&oceanbookpb.InsertOrderRequest{
+		Id:            2,
+		Price:         "0.0",
+		QuoteQuantity: "invalid",
+		Symbol:        "BTC/CNY",
+		Side:          oceanbookpb.Order_BID,
+	}, stream)
+	assert.Equal(t, ErrInvalidOrderQuoteQuantity, err)
+
+	err = svc.InsertOrder(&oceanbookpb.InsertOrderRequest{
+		Id:            3,
+		Price:         "0.0",
+		QuoteQuantity: "3.0",
+		Symbol:        "BTC/CNY",
+		Side:          oceanbookpb.Order_BID,
+	}, stream)
+	assert.Nil(t, err)
+	assert.Len(t, stream.trades, 1)
+	assert.Equal(t, "1.5", stream.trades[0].Quantity)
+}
+
+func TestMarketProtection(t *testing.T) {
+	svc := NewService()
+
+	_, err := svc.NewOrderBook(context.Background(), &oceanbookpb.NewOrderBookRequest{
+		Symbol: "BTC/CNY",
+		MarketProtection: &oceanbookpb.MarketProtection{
+			Deviation: "invalid",
+		},
+	})
+	assert.Equal(t, ErrInvalidMarketProtection, err)
+
+	_, err = svc.NewOrderBook(context.Background(), &oceanbookpb.NewOrderBookRequest{
+		Symbol: "BTC/CNY",
+		MarketProtection: &oceanbookpb.MarketProtection{
+			Deviation: "10",
+		},
+	})
+	assert.Nil(t, err)
+
+	stream := NewTestInsertOrderServer()
+	for id, price := range []string{"1.0", "2.0"} {
+		err = svc.InsertOrder(&oceanbookpb.InsertOrderRequest{
+			Id:       uint64(id + 1),
+			Price:    price,
+			Quantity: "1.0",
+			Symbol:   "BTC/CNY",
+			Side:     oceanbookpb.Order_ASK,
+		}, stream)
+		assert.Nil(t, err)
+	}
+
+	err = svc.InsertOrder(&oceanbookpb.InsertOrderRequest{
+		Id:       3,
+		Price:    "0.0",
+		Quantity: "2.0",
+		Symbol:   "BTC/CNY",
+		Side:     oceanbookpb.Order_BID,
+	}, stream)
+	assert.Equal(t, orderbook.ErrMarketProtectionCancelled, err)
+	assert.Len(t, stream.trades, 1)
+}
+
+func TestMarketSpec(t *testing.T) {
+	svc := NewService()
+
+	_, err := svc.NewOrderBook(context.Background(), &oceanbookpb.NewOrderBookRequest{
+		Symbol: "BTC/CNY",
+		MarketSpec: &oceanbookpb.MarketSpec{
+			MinQuantity: "2.0",
+			MaxQuantity: "1.0",
+		},
+	})
+	assert.Equal(t, ErrInvalidMarketSpec, err)
+
+	_, err = svc.NewOrderBook(context.Background(), &oceanbookpb.NewOrderBookRequest{
+		Symbol: "BTC/CNY",
+		MarketSpec: &oceanbookpb.MarketSpec{
+			TickSize:          "0.01",
+			QuantityPrecision: 4,
+			MinNotional:       "1.0",
+		},
+	})
+	assert.Nil(t, err)
+
+	stream := NewTestInsertOrderServer()
+	err = svc.InsertOrder(&oceanbookpb.InsertOrderRequest{
+		Id:       1,
+		Price:    "1.123456789",
+		Quantity: "1.0",
+		Symbol:   "BTC/CNY",
+		Side:     oceanbookpb.Order_BID,
+	}, stream)
+	assert.Equal(t, orderbook.ErrInvalidPriceTick, err)
+
+	err = svc.InsertOrder(&oceanbookpb.InsertOrderRequest{
+		Id:       2,
+		Price:    "1.12",
+		Quantity: "0.0000000001",
+		Symbol:   "BTC/CNY",
+		Side:     oceanbookpb.Order_BID,
+	}, stream)
+	assert.Equal(t, orderbook.ErrInvalidQuantityPrecision, err)
+
+	err = svc.InsertOrder(&oceanbookpb.InsertOrderRequest{
+		Id:       3,
+		Price:    "1.12",
+		Quantity: "0.5",
+		Symbol:   "BTC/CNY",
+		Side:     oceanbookpb.Order_BID,
+	}, stream)
+	assert.Equal(t, orderbook.ErrNotionalTooSmall, err)
+
+	err = svc.InsertOrder(&oceanbookpb.InsertOrderRequest{
+		Id:       4,
+		Price:    "1.12",
+		Quantity: "1.0",
+		Symbol:   "BTC/CNY",
+		Side:     oceanbookpb.Order_BID,
+	}, stream)
+	assert.Nil(t, err)
+}
+
+func TestAuction(t *testing.T) {
+	svc := NewService()
+
+	_, err := svc.NewOrderBook(context.Background(), &oceanbookpb.NewOrderBookRequest{
+		Symbol: "BTC/CNY",
+	})
+	assert.Nil(t, err)
+
+	stream := NewTestInsertOrderServer()
+	err = svc.Uncross(&oceanbookpb.UncrossRequest{Symbol: "BTC/CNY"}, stream)
+	assert.Equal(t, orderbook.ErrAuctionNotStarted, err)
+
+	_, err = svc.StartAuction(context.Background(), &oceanbookpb.StartAuctionRequest{
+		Symbol: "BTC/CNY",
+	})
+	assert.Nil(t, err)
+
+	for id, side := range []oceanbookpb.Order_Side{oceanbookpb.Order_BID, oceanbookpb.Order_ASK} {
+		err = svc.InsertOrder(&oceanbookpb.InsertOrderRequest{
+			Id:       uint64(id + 1),
+			Price:    "2.0",
+			Quantity: "1.0",
+			Symbol:   "BTC/CNY",
+			Side:     side,
+		}, stream)
+		assert.Nil(t, err)
+	}
+	assert.Equal(t, []*oceanbookpb.Trade{}, stream.trades)
+
+	err = svc.Uncross(&oceanbookpb.UncrossRequest{Symbol: "BTC/CNY"}, stream)
+	assert.Nil(t, err)
+	assert.Len(t, stream.trades, 1)
+	assert.Equal(t, "2", stream.trades[0].Price)
+}
+
+func TestTradingState(t *testing.T) {
+	svc := NewService()
+
+	_, err := svc.NewOrderBook(context.Background(), &oceanbookpb.NewOrderBookRequest{
+		Symbol:       "BTC/CNY",
+		TradingState: oceanbookpb.TradingState_CLOSED,
+	})
+	assert.Nil(t, err)
+
+	stream := NewTestInsertOrderServer()
+	err = svc.InsertOrder(&oceanbookpb.InsertOrderRequest{
+		Id:       1,
+		Price:    "1.0",
+		Quantity: "1.0",
+		Symbol:   "BTC/CNY",
+		Side:     oceanbookpb.Order_BID,
+	}, stream)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	_, err = svc.SetTradingState(context.Background(), &oceanbookpb.SetTradingStateRequest{
+		Symbol: "BTC/CNY",
+		State:  oceanbookpb.TradingState_CONTINUOUS,
+	})
+	assert.Nil(t, err)
+
+	response, err := svc.GetTradingState(context.Background(), &oceanbookpb.GetTradingStateRequest{
+		Symbol: "BTC/CNY",
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, oceanbookpb.TradingState_CONTINUOUS, response.State)
+
+	err = svc.InsertOrder(&oceanbookpb.InsertOrderRequest{
+		Id:       1,
+		Price:    "1.0",
+		Quantity: "1.0",
+		Symbol:   "BTC/CNY",
+		Side:     oceanbookpb.Order_BID,
+	}, stream)
+	assert.Nil(t, err)
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	svc := NewService()
+
+	_, err := svc.NewOrderBook(context.Background(), &oceanbookpb.NewOrderBookRequest{
+		Symbol: "BTC/CNY",
+		CircuitBreaker: &oceanbookpb.CircuitBreaker{
+			Band: "-1",
+		},
+	})
+	assert.Equal(t, ErrInvalidCircuitBreaker, err)
+
+	_, err = svc.NewOrderBook(context.Background(), &oceanbookpb.NewOrderBookRequest{
+		Symbol: "BTC/CNY",
+		CircuitBreaker: &oceanbookpb.CircuitBreaker{
+			Band:            "10",
+			WindowSeconds:   60,
+			ReopenByAuction: true,
+		},
+	})
+	assert.Nil(t, err)
+
+	stream := NewTestInsertOrderServer()
+	for _, request := range []*oceanbookpb.InsertOrderRequest{
+		{Id: 1, Price: "1.0", Quantity: "1.0", Symbol: "BTC/CNY", Side: oceanbookpb.Order_ASK},
+		{Id: 2, Price: "1.0", Quantity: "1.0", Symbol: "BTC/CNY", Side: oceanbookpb.Order_BID},
+		{Id: 3, Price: "1.2", Quantity: "1.0", Symbol: "BTC/CNY", Side: oceanbookpb.Order_ASK},
+	} {
+		err = svc.InsertOrder(request, stream)
+		assert.Nil(t, err)
+	}
+
+	err = svc.InsertOrder(&oceanbookpb.InsertOrderRequest{
+		Id:       4,
+		Price:    "1.2",
+		Quantity: "1.0",
+		Symbol:   "BTC/CNY",
+		Side:     oceanbookpb.Order_BID,
+	}, stream)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	response, err := svc.GetTradingState(context.Background(), &oceanbookpb.GetTradingStateRequest{
+		Symbol: "BTC/CNY",
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, oceanbookpb.TradingState_PRE_OPEN, response.State)
+}
+
+type SubscribeExecutionReportsServer struct {
+	grpc.ServerStream
+	ctx     context.Context
+	reports chan *oceanbookpb.ExecutionReport
+}
+
+func (x *SubscribeExecutionReportsServer) Context() context.Context {
+	return x.ctx
+}
+
+func (x *SubscribeExecutionReportsServer) Send(r *oceanbookpb.ExecutionReport) error {
+	x.reports <- r
+
+	return nil
+}
+
+func TestExecutionReports(t *testing.T) {
+	svc := NewService()
+
+	_, err := svc.NewOrderBook(context.Background(), &oceanbookpb.NewOrderBookRequest{
+		Symbol: "BTC/CNY",
+	})
+	assert.Nil(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	reportStream := &SubscribeExecutionReportsServer{
+		ctx:     ctx,
+		reports: make(chan *oceanbookpb.ExecutionReport, 16),
+	}
+
+	done := make(chan error)
+	go func() {
+		done <- svc.SubscribeExecutionReports(&oceanbookpb.SubscribeExecutionReportsRequest{
+			Symbol:  "BTC/CNY",
+			OwnerId: 2,
+		}, reportStream)
+	}()
+
+	// waits for the subscriber before inserting orders.
+	reportFeed := svc.reportFeeds["BTC/CNY"]
+	for {
+		reportFeed.Lock()
+		owners := len(reportFeed.owners)
+		reportFeed.Unlock()
+
+		if owners > 0 {
+			break
+		}
+		runtime.Gosched()
+	}
+
+	request := &oceanbookpb.InsertOrderRequest{
+		Id:       1,
+		Price:    "1.0",
+		Quantity: "1.0",
+		Symbol:   "BTC/CNY",
+		Side:     oceanbookpb.Order_ASK,
+		OwnerId:  1,
+	}
+	stream := NewTestInsertOrderServer()
+	err = svc.InsertOrder(request, stream)
+	assert.Nil(t, err)
+
+	request.OwnerId = 2
+	err = svc.InsertOrder(request, stream)
+	assert.Equal(t, orderbook.ErrDuplicateOrder, err)
+
+	report := <-reportStream.reports
+	assert.Equal(t, oceanbookpb.Order_REJECTED, report.Order.State)
+	assert.Equal(t, orderbook.ErrDuplicateOrder.Error(), report.Reason)
+
+	err = svc.InsertOrder(&oceanbookpb.InsertOrderRequest{
+		Id:                2,
+		Price:             "1.0",
+		Quantity:          "2.0",
+		Symbol:            "BTC/CNY",
+		Side:              oceanbookpb.Order_BID,
+		ImmediateOrCancel: true,
+		OwnerId:           2,
+	}, stream)
+	assert.Nil(t, err)
+	assert.Len(t, stream.trades, 1)
+
+	states := []oceanbookpb.Order_State{}
+	for i := 0; i < 4; i++ {
+		report = <-reportStream.reports
+		if report.Order != nil {
+			assert.Equal(t, "BTC/CNY", report.Order.Symbol)
+			states = append(states, report.Order.State)
+		}
+	}
+	assert.Equal(t, []oceanbookpb.Order_State{
+		oceanbookpb.Order_PENDING,
+		oceanbookpb.Order_PARTIALLY_FILLED,
+		oceanbookpb.Order_CANCELLED,
+	}, states)
+	assert.Equal(t, "1", report.Order.FilledQuantity)
+	assert.Empty(t, reportStream.reports)
+
+	cancel()
+	assert.Equal(t, context.Canceled, <-done)
+}
+
+func TestSubscribeExecutionReports(t *testing.T) {
+	svc := NewService()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := &SubscribeExecutionReportsServer{
+		ctx:     ctx,
+		reports: make(chan *oceanbookpb.ExecutionReport, 16),
+	}
+
+	err := svc.SubscribeExecutionReports(&oceanbookpb.SubscribeExecutionReportsRequest{Symbol: "BTC/CNY"}, stream)
+	assert.Equal(t, ErrOrderBookNotFound, err)
+
+	_, err = svc.NewOrderBook(context.Background(), &oceanbookpb.NewOrderBookRequest{
+		Symbol: "BTC/CNY",
+	})
+	assert.Nil(t, err)
+
+	done := make(chan error)
+	go func() {
+		done <- svc.SubscribeExecutionReports(&oceanbookpb.SubscribeExecutionReportsRequest{
+			Symbol:  "BTC/CNY",
+			OwnerId: 1,
+		}, stream)
+	}()
+
+	// waits for the subscriber before trading.
+	reportFeed := svc.reportFeeds["BTC/CNY"]
+	for {
+		reportFeed.Lock()
+		owners := len(reportFeed.owners)
+		reportFeed.Unlock()
+
+		if owners > 0 {
+			break
+		}
+		runtime.Gosched()
+	}
+
+	for _, request := range []*oceanbookpb.InsertOrderRequest{
+		{Id: 1, Price: "1.0", Quantity: "2.0", Side: oceanbookpb.Order_ASK, OwnerId: 1},
+		{Id: 2, Price: "1.0", Quantity: "1.0", Side: oceanbookpb.Order_BID, OwnerId: 2},
+	} {
+		request.Symbol = "BTC/CNY"
+		err = svc.InsertOrder(request, NewTestInsertOrderServer())
+		assert.Nil(t, err)
+	}
+
+	_, err = svc.CancelOrder(context.Background(), &oceanbookpb.CancelOrderRequest{
+		Symbol:  "BTC/CNY",
+		OrderId: 1,
+	})
+	assert.Nil(t, err)
+
+	report := <-stream.reports
+	assert.Equal(t, oceanbookpb.Order_PENDING, report.Order.State)
+
+	// the maker sees its fill without the order and owner of taker.
+	report = <-stream.reports
+	assert.NotNil(t, report.Trade.CreatedAt)
+	report.Trade.CreatedAt = nil
+	assert.Equal(t, &oceanbookpb.Trade{
+		Id:       1,
+		Price:    "1",
+		Quantity: "1",
+		MakerId:  1,
+
+		MakerOwnerId: 1,
+	}, report.Trade)
+
+	report = <-stream.reports
+	assert.Equal(t, "BTC/CNY", report.Order.Symbol)
+	assert.Equal(t, oceanbookpb.Order_PARTIALLY_FILLED, report.Order.State)
+
+	report = <-stream.reports
+	assert.Equal(t, oceanbookpb.Order_CANCELLED, report.Order.State)
+	assert.Empty(t, stream.reports)
+
+	cancel()
+	assert.Equal(t, context.Canceled, <-done)
+	assert.Empty(t, reportFeed.owners)
+}
+
+func TestListOpenOrders(t *testing.T) {
+	svc := NewService()
+
+	_, err := svc.NewOrderBook(context.Background(), &oceanbookpb.NewOrderBookRequest{
+		Symbol: "BTC/CNY",
+	})
+	assert.Nil(t, err)
+
+	stream := NewTestInsertOrderServer()
+	for id := uint64(1); id <= 3; id++ {
+		err = svc.InsertOrder(&oceanbookpb.InsertOrderRequest{
+			Id:       id,
+			Price:    "1.0",
+			Quantity: "1.0",
+			Symbol:   "BTC/CNY",
+			Side:     oceanbookpb.Order_BID,
+			OwnerId:  id % 2,
+		}, stream)
+		assert.Nil(t, err)
+	}
+
+	o, err := svc.GetOrder(context.Background(), &oceanbookpb.GetOrderRequest{
+		Symbol:  "BTC/CNY",
+		OrderId: 2,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "BTC/CNY", o.Symbol)
+	assert.Equal(t, oceanbookpb.Order_PENDING, o.State)
+	assert.Equal(t, "1", o.RemainingQuantity)
+
+	_, err = svc.GetOrder(context.Background(), &oceanbookpb.GetOrderRequest{
+		Symbol:  "BTC/CNY",
+		OrderId: 4,
+	})
+	assert.Equal(t, orderbook.ErrOrderNotFound, err)
+
+	response, err := svc.ListOpenOrders(context.Background(), &oceanbookpb.ListOpenOrdersRequest{
+		Symbol:   "BTC/CNY",
+		Sides:    []oceanbookpb.Order_Side{oceanbookpb.Order_BID},
+		OwnerId:  1,
+		PageSize: 1,
+	})
+	assert.Nil(t, err)
+	assert.Len(t, response.Orders, 1)
+	assert.Equal(t, uint64(1), response.Orders[0].Id)
+	assert.Equal(t, uint64(2), response.NextPageToken)
+
+	response, err = svc.ListOpenOrders(context.Background(), &oceanbookpb.ListOpenOrdersRequest{
+		Symbol:    "BTC/CNY",
+		OwnerId:   1,
+		PageToken: response.NextPageToken,
+		PageSize:  2,
+	})
+	assert.Nil(t, err)
+	assert.Len(t, response.Orders, 1)
+	assert.Equal(t, uint64(3), response.Orders[0].Id)
+	assert.Equal(t, uint64(0), response.NextPageToken)
+}
+
+func TestMassCancel(t *testing.T) {
+	svc := NewService()
+
+	_, err := svc.MassCancel(context.Background(), &oceanbookpb.MassCancelRequest{
+		Symbol: "BTC/CNY",
+	})
+	assert.Equal(t, ErrOrderBookNotFound, err)
+
+	_, err = svc.NewOrderBook(context.Background(), &oceanbookpb.NewOrderBookRequest{
+		Symbol: "BTC/CNY",
+	})
+	assert.Nil(t, err)
+
+	stream := NewTestInsertOrderServer()
+	for id := uint64(1); id <= 3; id++ {
+		err = svc.InsertOrder(&oceanbookpb.InsertOrderRequest{
+			Id:       id,
+			Price:    "1.0",
+			Quantity: "1.0",
+			Symbol:   "BTC/CNY",
+			Side:     oceanbookpb.Order_BID,
+			OwnerId:  id % 2,
+		}, stream)
+		assert.Nil(t, err)
+	}
+
+	_, err = svc.MassCancel(context.Background(), &oceanbookpb.MassCancelRequest{
+		Symbol:   "BTC/CNY",
+		MinPrice: "invalid",
+	})
+	assert.Equal(t, ErrInvalidOrderPrice, err)
+
+	response, err := svc.MassCancel(context.Background(), &oceanbookpb.MassCancelRequest{
+		Symbol:   "BTC/CNY",
+		Sides:    []oceanbookpb.Order_Side{oceanbookpb.Order_BID},
+		OwnerId:  1,
+		MinPrice: "1.0",
+		MaxPrice: "2.0",
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []uint64{1, 3}, response.OrderIds)
+
+	od, _ := svc.orderbooks["BTC/CNY"]
+	assert.Equal(t, 1, od.Bids.Size())
+}
+
+func TestGetDepth(t *testing.T) {
+	svc := NewService()
+
+	_, err := svc.GetDepth(context.Background(), &oceanbookpb.GetDepthRequest{
+		Symbol: "BTC/CNY",
+	})
+	assert.Equal(t, ErrOrderBookNotFound, err)
+
+	_, err = svc.NewOrderBook(context.Background(), &oceanbookpb.NewOrderBookRequest{
+		Symbol: "BTC/CNY",
+	})
+	assert.Nil(t, err)
+
+	stream := NewTestInsertOrderServer()
+	for id, price := range []string{"1.25", "1.3", "0.9"} {
+		err = svc.InsertOrder(&oceanbookpb.InsertOrderRequest{
+			Id:       uint64(id + 1),
+			Price:    price,
+			Quantity: "1.0",
+			Symbol:   "BTC/CNY",
+			Side:     oceanbookpb.Order_BID,
+		}, stream)
+		assert.Nil(t, err)
+	}
+
+	depth, err := svc.GetDepth(context.Background(), &oceanbookpb.GetDepthRequest{
+		Symbol: "BTC/CNY",
+	})
+	assert.Nil(t, err)
+	assert.Len(t, depth.Bids, 3)
+
+	_, err = svc.GetDepth(context.Background(), &oceanbookpb.GetDepthRequest{
+		Symbol:    "BTC/CNY",
+		Increment: "-0.1",
+	})
+	assert.Equal(t, ErrInvalidDepthIncrement, err)
+
+	depth, err = svc.GetDepth(context.Background(), &oceanbookpb.GetDepthRequest{
+		Symbol:    "BTC/CNY",
+		Increment: "0.5",
+		Limit:     1,
+	})
+	assert.Nil(t, err)
+	assert.Len(t, depth.Bids, 1)
+	assert.Equal(t, "1", depth.Bids[0].Price)
+	assert.Equal(t, "2", depth.Bids[0].Quantity)
+	assert.Equal(t, uint64(2), depth.Bids[0].OrdersCount)
+}
+
+type SubscribeDepthServer struct {
+	grpc.ServerStream
+	ctx    context.Context
+	events chan *oceanbookpb.DepthEvent
 }
 
-func NewTestInsertOrderServer() *InsertOrderServer {
-	return &InsertOrderServer{
-		trades: []*oceanbookpb.Trade{},
+func (x *SubscribeDepthServer) Context() context.Context {
+	return x.ctx
+}
+
+func (x *SubscribeDepthServer) Send(event *oceanbookpb.DepthEvent) error {
+	x.events <- event
+
+	return nil
+}
+
+func TestSubscribeDepth(t *testing.T) {
+	svc := NewService()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := &SubscribeDepthServer{
+		ctx:    ctx,
+		events: make(chan *oceanbookpb.DepthEvent, 16),
+	}
+
+	err := svc.SubscribeDepth(&oceanbookpb.SubscribeDepthRequest{Symbol: "BTC/CNY"}, stream)
+	assert.Equal(t, ErrOrderBookNotFound, err)
+
+	_, err = svc.NewOrderBook(context.Background(), &oceanbookpb.NewOrderBookRequest{
+		Symbol: "BTC/CNY",
+	})
+	assert.Nil(t, err)
+
+	insertStream := NewTestInsertOrderServer()
+	err = svc.InsertOrder(&oceanbookpb.InsertOrderRequest{
+		Id:       1,
+		Price:    "1.0",
+		Quantity: "1.0",
+		Symbol:   "BTC/CNY",
+		Side:     oceanbookpb.Order_BID,
+	}, insertStream)
+	assert.Nil(t, err)
+
+	done := make(chan error)
+	go func() {
+		done <- svc.SubscribeDepth(&oceanbookpb.SubscribeDepthRequest{Symbol: "BTC/CNY"}, stream)
+	}()
+
+	snapshot := (<-stream.events).GetSnapshot()
+	assert.Equal(t, uint64(1), snapshot.Sequence)
+	assert.Len(t, snapshot.Bids, 1)
+
+	err = svc.InsertOrder(&oceanbookpb.InsertOrderRequest{
+		Id:       2,
+		Price:    "1.0",
+		Quantity: "0.4",
+		Symbol:   "BTC/CNY",
+		Side:     oceanbookpb.Order_ASK,
+	}, insertStream)
+	assert.Nil(t, err)
+
+	update := (<-stream.events).GetUpdate()
+	assert.Equal(t, uint64(2), update.Sequence)
+	assert.Equal(t, oceanbookpb.Order_BID, update.Side)
+	assert.Equal(t, "0.6", update.PriceLevel.Quantity)
+	assert.Equal(t, uint64(1), update.PriceLevel.OrdersCount)
+
+	cancel()
+	assert.Equal(t, context.Canceled, <-done)
+	assert.Empty(t, svc.depthFeeds["BTC/CNY"].subscribers)
+}
+
+type SubscribeTradesServer struct {
+	grpc.ServerStream
+	ctx    context.Context
+	trades chan *oceanbookpb.Trade
+}
+
+func (x *SubscribeTradesServer) Context() context.Context {
+	return x.ctx
+}
+
+func (x *SubscribeTradesServer) Send(t *oceanbookpb.Trade) error {
+	x.trades <- t
+
+	return nil
+}
+
+func TestSubscribeTrades(t *testing.T) {
+	svc := NewService()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := &SubscribeTradesServer{
+		ctx:    ctx,
+		trades: make(chan *oceanbookpb.Trade, 16),
+	}
+
+	err := svc.SubscribeTrades(&oceanbookpb.SubscribeTradesRequest{Symbol: "BTC/CNY"}, stream)
+	assert.Equal(t, ErrOrderBookNotFound, err)
+
+	err = svc.SubscribeTrades(&oceanbookpb.SubscribeTradesRequest{
+		Symbol:               "BTC/CNY",
+		SlowSubscriberPolicy: oceanbookpb.SubscribeTradesRequest_SlowSubscriberPolicy(-1),
+	}, stream)
+	assert.Equal(t, ErrInvalidSlowSubscriberPolicy, err)
+
+	_, err = svc.NewOrderBook(context.Background(), &oceanbookpb.NewOrderBookRequest{
+		Symbol: "BTC/CNY",
+	})
+	assert.Nil(t, err)
+
+	done := make(chan error)
+	go func() {
+		done <- svc.SubscribeTrades(&oceanbookpb.SubscribeTradesRequest{
+			Symbol:               "BTC/CNY",
+			SlowSubscriberPolicy: oceanbookpb.SubscribeTradesRequest_DROP,
+		}, stream)
+	}()
+
+	// waits for the subscriber before trading.
+	tradeFeed := svc.tradeFeeds["BTC/CNY"]
+	for {
+		tradeFeed.Lock()
+		subscribers := len(tradeFeed.subscribers)
+		tradeFeed.Unlock()
+
+		if subscribers > 0 {
+			break
+		}
+		runtime.Gosched()
+	}
+
+	insertStream := NewTestInsertOrderServer()
+	for id, side := range []oceanbookpb.Order_Side{oceanbookpb.Order_ASK, oceanbookpb.Order_BID} {
+		err = svc.InsertOrder(&oceanbookpb.InsertOrderRequest{
+			Id:       uint64(id + 1),
+			Price:    "1.0",
+			Quantity: "1.0",
+			Symbol:   "BTC/CNY",
+			Side:     side,
+			OwnerId:  uint64(id + 1),
+		}, insertStream)
+		assert.Nil(t, err)
 	}
+
+	trade := <-stream.trades
+
+	// every trade on the feed carries its execution time.
+	createdAt, err := ptypes.Timestamp(trade.CreatedAt)
+	assert.Nil(t, err)
+	assert.WithinDuration(t, time.Now(), createdAt, time.Minute)
+
+	trade.CreatedAt = nil
+	assert.Equal(t, &oceanbookpb.Trade{
+		Id:       1,
+		Symbol:   "BTC/CNY",
+		Price:    "1",
+		Quantity: "1",
+	}, trade)
+
+	cancel()
+	assert.Equal(t, context.Canceled, <-done)
 }
 
-func (x *InsertOrderServer) Send(t *oceanbookpb.Trade) error {
-	x.trades = append(x.trades, t)
+type SubscribeOrderBookServer struct {
+	grpc.ServerStream
+	ctx    context.Context
+	events chan *oceanbookpb.OrderBookEvent
+}
+
+func (x *SubscribeOrderBookServer) Context() context.Context {
+	return x.ctx
+}
+
+func (x *SubscribeOrderBookServer) Send(event *oceanbookpb.OrderBookEvent) error {
+	x.events <- event
 
 	return nil
 }
 
-func TestInsertOrder(t *testing.T) {
+func TestSubscribeOrderBook(t *testing.T) {
 	svc := NewService()
 
-	request := &oceanbookpb.NewOrderBookRequest{
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := &SubscribeOrderBookServer{
+		ctx:    ctx,
+		events: make(chan *oceanbookpb.OrderBookEvent, 16),
+	}
+
+	err := svc.SubscribeOrderBook(&oceanbookpb.SubscribeOrderBookRequest{Symbol: "BTC/CNY"}, stream)
+	assert.Equal(t, ErrOrderBookNotFound, err)
+
+	_, err = svc.NewOrderBook(context.Background(), &oceanbookpb.NewOrderBookRequest{
 		Symbol: "BTC/CNY",
+	})
+	assert.Nil(t, err)
+
+	insertStream := NewTestInsertOrderServer()
+	for id := uint64(1); id <= 2; id++ {
+		err = svc.InsertOrder(&oceanbookpb.InsertOrderRequest{
+			Id:       id,
+			Price:    "1.0",
+			Quantity: "1.0",
+			Symbol:   "BTC/CNY",
+			Side:     oceanbookpb.Order_BID,
+		}, insertStream)
+		assert.Nil(t, err)
 	}
 
-	newOrderBookResponse, err := svc.NewOrderBook(context.Background(), request)
+	done := make(chan error)
+	go func() {
+		done <- svc.SubscribeOrderBook(&oceanbookpb.SubscribeOrderBookRequest{Symbol: "BTC/CNY"}, stream)
+	}()
+
+	snapshot := (<-stream.events).GetSnapshot()
+	assert.Equal(t, uint64(2), snapshot.Sequence)
+	assert.Len(t, snapshot.Bids, 2)
+	assert.Equal(t, uint32(2), snapshot.Bids[1].Position)
+
+	err = svc.InsertOrder(&oceanbookpb.InsertOrderRequest{
+		Id:       3,
+		Price:    "1.0",
+		Quantity: "0.4",
+		Symbol:   "BTC/CNY",
+		Side:     oceanbookpb.Order_ASK,
+	}, insertStream)
 	assert.Nil(t, err)
-	assert.Equal(t, &oceanbookpb.NewOrderBookResponse{}, newOrderBookResponse)
+
+	update := (<-stream.events).GetUpdate()
+	assert.Equal(t, uint64(3), update.Sequence)
+	assert.Equal(t, oceanbookpb.OrderEvent_EXECUTE, update.Type)
+	assert.Equal(t, snapshot.Bids[0].OrderId, update.OrderId)
+	assert.Equal(t, "0.4", update.ExecutedQuantity)
+	assert.Equal(t, "0.6", update.Quantity)
+
+	_, err = svc.CancelOrder(context.Background(), &oceanbookpb.CancelOrderRequest{
+		OrderId: 2,
+		Symbol:  "BTC/CNY",
+	})
+	assert.Nil(t, err)
+
+	update = (<-stream.events).GetUpdate()
+	assert.Equal(t, uint64(4), update.Sequence)
+	assert.Equal(t, oceanbookpb.OrderEvent_DELETE, update.Type)
+	assert.Equal(t, snapshot.Bids[1].OrderId, update.OrderId)
+
+	cancel()
+	assert.Equal(t, context.Canceled, <-done)
+}
+
+func TestAmendOrder(t *testing.T) {
+	svc := NewService()
 
 	stream := NewTestInsertOrderServer()
+	err := svc.AmendOrder(&oceanbookpb.AmendOrderRequest{
+		OrderId: 1,
+		Symbol:  "BTC/CNY",
+	}, stream)
+	assert.Equal(t, ErrOrderBookNotFound, err)
+
+	_, err = svc.NewOrderBook(context.Background(), &oceanbookpb.NewOrderBookRequest{
+		Symbol: "BTC/CNY",
+	})
+	assert.Nil(t, err)
+
 	err = svc.InsertOrder(&oceanbookpb.InsertOrderRequest{
 		Id:       1,
 		Price:    "1.0",
@@ -66,22 +1096,38 @@ func TestInsertOrder(t *testing.T) {
 		Side:     oceanbookpb.Order_ASK,
 	}, stream)
 	assert.Nil(t, err)
-	assert.Equal(t, []*oceanbookpb.Trade{}, stream.trades)
 
 	err = svc.InsertOrder(&oceanbookpb.InsertOrderRequest{
 		Id:       2,
-		Price:    "2.0",
+		Price:    "0.5",
 		Quantity: "1.0",
 		Symbol:   "BTC/CNY",
 		Side:     oceanbookpb.Order_BID,
 	}, stream)
 	assert.Nil(t, err)
+
+	err = svc.AmendOrder(&oceanbookpb.AmendOrderRequest{
+		OrderId: 2,
+		Symbol:  "BTC/CNY",
+		Price:   "invalid",
+	}, stream)
+	assert.Equal(t, ErrInvalidOrderPrice, err)
+
+	err = svc.AmendOrder(&oceanbookpb.AmendOrderRequest{
+		OrderId: 2,
+		Symbol:  "BTC/CNY",
+		Price:   "1.0",
+	}, stream)
+	assert.Nil(t, err)
+	assert.Len(t, stream.trades, 1)
+	assert.NotNil(t, stream.trades[0].CreatedAt)
+	stream.trades[0].CreatedAt = nil
 	assert.Equal(t, []*oceanbookpb.Trade{
 		{
+			Id:       1,
 			Price:    "1",
 			Quantity: "1",
 			TakerId:  2,
-			MakerId:  1,
 		},
 	}, stream.trades)
 }
@@ -120,9 +1166,48 @@ func TestCancelOrder(t *testing.T) {
 		Symbol:  "BTC/CNY",
 	})
 	assert.Nil(t, err)
-	assert.Equal(t, &oceanbookpb.CancelOrderResponse{}, cancelOrderResponse)
+	assert.Equal(t, uint64(1), cancelOrderResponse.Order.Id)
+	assert.Equal(t, oceanbookpb.Order_CANCELLED, cancelOrderResponse.Order.State)
 
-	orderbook, _ := svc.orderbooks[request.Symbol]
-	assert.Equal(t, 0, orderbook.Bids.Size())
-	assert.Equal(t, 0, orderbook.Asks.Size())
+	od, _ := svc.orderbooks[request.Symbol]
+	assert.Equal(t, 0, od.Bids.Size())
+	assert.Equal(t, 0, od.Asks.Size())
+	assert.Equal(t, 0, od.GetDepth().Asks.Size())
+
+	cancelOrderResponse, err = svc.CancelOrder(context.Background(), &oceanbookpb.CancelOrderRequest{
+		OrderId: 1,
+		Symbol:  "BTC/CNY",
+	})
+	assert.Equal(t, orderbook.ErrOrderNotFound, err)
+	assert.Nil(t, cancelOrderResponse)
+}
+
+func TestInsertPostOnlyOrder(t *testing.T) {
+	svc := NewService()
+
+	_, err := svc.NewOrderBook(context.Background(), &oceanbookpb.NewOrderBookRequest{
+		Symbol: "BTC/CNY",
+	})
+	assert.Nil(t, err)
+
+	stream := NewTestInsertOrderServer()
+	err = svc.InsertOrder(&oceanbookpb.InsertOrderRequest{
+		Id:       1,
+		Price:    "1.0",
+		Quantity: "2.0",
+		Symbol:   "BTC/CNY",
+		Side:     oceanbookpb.Order_ASK,
+	}, stream)
+	assert.Nil(t, err)
+
+	err = svc.InsertOrder(&oceanbookpb.InsertOrderRequest{
+		Id:       2,
+		Price:    "1.0",
+		Quantity: "1.0",
+		Symbol:   "BTC/CNY",
+		Side:     oceanbookpb.Order_BID,
+		PostOnly: true,
+	}, stream)
+	assert.Equal(t, orderbook.ErrPostOnlyOrderWouldCross, err)
+	assert.Equal(t, []*oceanbookpb.Trade{}, stream.trades)
 }
@@ -1,7 +1,10 @@
 package trade
 
 import (
+	"time"
+
 	"github.com/draveness/oceanbook/api/protobuf-spec/oceanbookpb"
+	"github.com/golang/protobuf/ptypes"
 	"github.com/shopspring/decimal"
 )
 
@@ -13,16 +16,63 @@ type Trade struct {
 	Quantity decimal.Decimal
 	TakerID  uint64
 	MakerID  uint64
+
+	TakerOwnerID uint64
+	MakerOwnerID uint64
+
+	// CreatedAt is the execution time of trade.
+	CreatedAt time.Time
 }
 
 // Serialize returns protobuf encoded trade.
 func (t *Trade) Serialize() *oceanbookpb.Trade {
-	return &oceanbookpb.Trade{
+	serialized := &oceanbookpb.Trade{
 		Id:       t.ID,
 		Symbol:   t.Symbol,
 		Price:    t.Price.String(),
 		Quantity: t.Quantity.String(),
 		TakerId:  t.TakerID,
 		MakerId:  t.MakerID,
+
+		TakerOwnerId: t.TakerOwnerID,
+		MakerOwnerId: t.MakerOwnerID,
 	}
+
+	if !t.CreatedAt.IsZero() {
+		serialized.CreatedAt, _ = ptypes.TimestampProto(t.CreatedAt)
+	}
+
+	return serialized
+}
+
+// TakerView returns the trade seen by its taker, order and owner of the maker
+// are hidden.
+func (t *Trade) TakerView() *Trade {
+	view := *t
+	view.MakerID = 0
+	view.MakerOwnerID = 0
+
+	return &view
+}
+
+// MakerView returns the trade seen by its maker, order and owner of the taker
+// are hidden.
+func (t *Trade) MakerView() *Trade {
+	view := *t
+	view.TakerID = 0
+	view.TakerOwnerID = 0
+
+	return &view
+}
+
+// PublicView returns the trade seen by the market, orders and owners of both
+// sides are hidden.
+func (t *Trade) PublicView() *Trade {
+	view := *t
+	view.TakerID = 0
+	view.MakerID = 0
+	view.TakerOwnerID = 0
+	view.MakerOwnerID = 0
+
+	return &view
 }